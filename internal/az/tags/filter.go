@@ -25,3 +25,15 @@ func Filter(tagsMap map[string]*string, tagNames ...string) map[string]*string {
 
 	return tagsRet
 }
+
+// MatchesAll returns whether every one of the required tags is present in the tag map with the same value
+func MatchesAll(tagsMap map[string]*string, requiredTags map[string]interface{}) bool {
+	for requiredName, requiredValue := range requiredTags {
+		value, ok := tagsMap[requiredName]
+		if !ok || value == nil || *value != requiredValue.(string) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -22,3 +22,48 @@ func TestFilter(t *testing.T) {
 		t.Fatalf("Expected %v in filtered tag map, got %v", valueData[1], *filtered["key2"])
 	}
 }
+
+func TestMatchesAll(t *testing.T) {
+	valueData := [2]string{"value1", "value2"}
+	testData := map[string]*string{
+		"key1": &valueData[0],
+		"key2": &valueData[1],
+		"key3": nil,
+	}
+
+	testCases := []struct {
+		Required map[string]interface{}
+		Expected bool
+	}{
+		{
+			Required: map[string]interface{}{},
+			Expected: true,
+		},
+		{
+			Required: map[string]interface{}{"key1": "value1"},
+			Expected: true,
+		},
+		{
+			Required: map[string]interface{}{"key1": "value1", "key2": "value2"},
+			Expected: true,
+		},
+		{
+			Required: map[string]interface{}{"key1": "value2"},
+			Expected: false,
+		},
+		{
+			Required: map[string]interface{}{"key3": ""},
+			Expected: false,
+		},
+		{
+			Required: map[string]interface{}{"key4": "value1"},
+			Expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if actual := MatchesAll(testData, tc.Required); actual != tc.Expected {
+			t.Fatalf("Expected %t for %+v but got %t", tc.Expected, tc.Required, actual)
+		}
+	}
+}
@@ -1,11 +1,24 @@
 package features
 
 type UserFeatures struct {
+	ComputeQuota           ComputeQuotaFeatures
 	ResourceGroup          ResourceGroupFeatures
 	VirtualMachine         VirtualMachineFeatures
 	VirtualMachineScaleSet VirtualMachineScaleSetFeatures
 }
 
+type ComputeQuotaFeatures struct {
+	PreflightCheck ComputeQuotaPreflightCheck
+}
+
+// ComputeQuotaPreflightCheck determines what happens when a plan would exceed the remaining Compute quota
+type ComputeQuotaPreflightCheck string
+
+const (
+	ComputeQuotaPreflightCheckDisabled ComputeQuotaPreflightCheck = "Disabled"
+	ComputeQuotaPreflightCheckError    ComputeQuotaPreflightCheck = "Error"
+)
+
 type ResourceGroupFeatures struct {
 	PreventDeletionIfContainsResources bool
 }
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
 )
@@ -10,6 +11,24 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 	// NOTE: if there's only one nested field these want to be Required (since there's no point
 	//       specifying the block otherwise) - however for 2+ they should be optional
 	featuresMap := map[string]*pluginsdk.Schema{
+		"compute_quota": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"preflight_check": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							string(features.ComputeQuotaPreflightCheckDisabled),
+							string(features.ComputeQuotaPreflightCheckError),
+						}, false),
+					},
+				},
+			},
+		},
+
 		"virtual_machine": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
@@ -104,6 +123,16 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 
 	val := input[0].(map[string]interface{})
 
+	if raw, ok := val["compute_quota"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			computeQuotaRaw := items[0].(map[string]interface{})
+			if v, ok := computeQuotaRaw["preflight_check"]; ok {
+				featuresMap.ComputeQuota.PreflightCheck = features.ComputeQuotaPreflightCheck(v.(string))
+			}
+		}
+	}
+
 	if raw, ok := val["virtual_machine"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 && items[0] != nil {
@@ -18,6 +18,9 @@ func TestExpandFeatures(t *testing.T) {
 			Name:  "Empty Block",
 			Input: []interface{}{},
 			Expected: features.UserFeatures{
+				ComputeQuota: features.ComputeQuotaFeatures{
+					PreflightCheck: features.ComputeQuotaPreflightCheckDisabled,
+				},
 				VirtualMachine: features.VirtualMachineFeatures{
 					DeleteOSDiskOnDeletion:     true,
 					GracefulShutdown:           false,
@@ -37,6 +40,11 @@ func TestExpandFeatures(t *testing.T) {
 			Name: "Complete Enabled",
 			Input: []interface{}{
 				map[string]interface{}{
+					"compute_quota": []interface{}{
+						map[string]interface{}{
+							"preflight_check": "Error",
+						},
+					},
 					"resource_group": []interface{}{
 						map[string]interface{}{
 							"prevent_deletion_if_contains_resources": true,
@@ -64,6 +72,9 @@ func TestExpandFeatures(t *testing.T) {
 				},
 			},
 			Expected: features.UserFeatures{
+				ComputeQuota: features.ComputeQuotaFeatures{
+					PreflightCheck: features.ComputeQuotaPreflightCheckError,
+				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: true,
 				},
@@ -83,6 +94,11 @@ func TestExpandFeatures(t *testing.T) {
 			Name: "Complete Disabled",
 			Input: []interface{}{
 				map[string]interface{}{
+					"compute_quota": []interface{}{
+						map[string]interface{}{
+							"preflight_check": "Disabled",
+						},
+					},
 					"resource_group": []interface{}{
 						map[string]interface{}{
 							"prevent_deletion_if_contains_resources": false,
@@ -110,6 +126,9 @@ func TestExpandFeatures(t *testing.T) {
 				},
 			},
 			Expected: features.UserFeatures{
+				ComputeQuota: features.ComputeQuotaFeatures{
+					PreflightCheck: features.ComputeQuotaPreflightCheckDisabled,
+				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: false,
 				},
@@ -136,6 +155,54 @@ func TestExpandFeatures(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesComputeQuota(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"compute_quota": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				ComputeQuota: features.ComputeQuotaFeatures{
+					PreflightCheck: features.ComputeQuotaPreflightCheckDisabled,
+				},
+			},
+		},
+		{
+			Name: "Preflight Check Error",
+			Input: []interface{}{
+				map[string]interface{}{
+					"compute_quota": []interface{}{
+						map[string]interface{}{
+							"preflight_check": "Error",
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ComputeQuota: features.ComputeQuotaFeatures{
+					PreflightCheck: features.ComputeQuotaPreflightCheckError,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.ComputeQuota, testCase.Expected.ComputeQuota) {
+			t.Fatalf("Expected %+v but got %+v", result.ComputeQuota, testCase.Expected.ComputeQuota)
+		}
+	}
+}
+
 func TestExpandFeaturesResourceGroup(t *testing.T) {
 	testData := []struct {
 		Name     string
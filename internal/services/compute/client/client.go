@@ -8,6 +8,9 @@ import (
 type Client struct {
 	AvailabilitySetsClient          *compute.AvailabilitySetsClient
 	DisksClient                     *compute.DisksClient
+	SnapshotsClient                 *compute.SnapshotsClient
+	SSHPublicKeysClient             *compute.SSHPublicKeysClient
+	UsageClient                     *compute.UsageClient
 	VMExtensionImageClient          *compute.VirtualMachineExtensionImagesClient
 	VMExtensionClient               *compute.VirtualMachineExtensionsClient
 	VMScaleSetClient                *compute.VirtualMachineScaleSetsClient
@@ -16,6 +19,7 @@ type Client struct {
 	VMScaleSetVMsClient             *compute.VirtualMachineScaleSetVMsClient
 	VMClient                        *compute.VirtualMachinesClient
 	VMImageClient                   *compute.VirtualMachineImagesClient
+	VMSizesClient                   *compute.VirtualMachineSizesClient
 	ImageClient                     *compute.ImagesClient
 }
 
@@ -26,6 +30,15 @@ func NewClient(o *common.ClientOptions) *Client {
 	disksClient := compute.NewDisksClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&disksClient.Client, o.ResourceManagerAuthorizer)
 
+	snapshotsClient := compute.NewSnapshotsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&snapshotsClient.Client, o.ResourceManagerAuthorizer)
+
+	sshPublicKeysClient := compute.NewSSHPublicKeysClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&sshPublicKeysClient.Client, o.ResourceManagerAuthorizer)
+
+	usageClient := compute.NewUsageClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&usageClient.Client, o.ResourceManagerAuthorizer)
+
 	imagesClient := compute.NewImagesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&imagesClient.Client, o.ResourceManagerAuthorizer)
 
@@ -50,6 +63,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	vmScaleSetVMsClient := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmScaleSetVMsClient.Client, o.ResourceManagerAuthorizer)
 
+	vmSizesClient := compute.NewVirtualMachineSizesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&vmSizesClient.Client, o.ResourceManagerAuthorizer)
+
 	vmClient := compute.NewVirtualMachinesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&vmClient.Client, o.ResourceManagerAuthorizer)
 
@@ -59,6 +75,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	return &Client{
 		AvailabilitySetsClient:          &availabilitySetsClient,
 		DisksClient:                     &disksClient,
+		SnapshotsClient:                 &snapshotsClient,
+		SSHPublicKeysClient:             &sshPublicKeysClient,
+		UsageClient:                     &usageClient,
 		VMExtensionImageClient:          &vmExtensionImageClient,
 		VMExtensionClient:               &vmExtensionClient,
 		VMScaleSetClient:                &vmScaleSetClient,
@@ -67,6 +86,7 @@ func NewClient(o *common.ClientOptions) *Client {
 		VMScaleSetVMsClient:             &vmScaleSetVMsClient,
 		VMClient:                        &vmClient,
 		VMImageClient:                   &vmImageClient,
+		VMSizesClient:                   &vmSizesClient,
 		ImageClient:                     &imageClient,
 	}
 }
@@ -0,0 +1,172 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+func listComputeUsages(ctx context.Context, client *compute.UsageClient, loc string) ([]compute.Usage, error) {
+	usages := make([]compute.Usage, 0)
+
+	iterator, err := client.ListComplete(ctx, loc)
+	if err != nil {
+		return nil, fmt.Errorf("listing Compute Usages in location %q: %+v", loc, err)
+	}
+	for iterator.NotDone() {
+		usages = append(usages, iterator.Value())
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Compute Usages in location %q: %+v", loc, err)
+		}
+	}
+
+	return usages, nil
+}
+
+func computeUsageValues(input compute.Usage) (currentValue int64, limit int64) {
+	if input.CurrentValue != nil {
+		currentValue = int64(*input.CurrentValue)
+	}
+	if input.Limit != nil {
+		limit = *input.Limit
+	}
+	return
+}
+
+// computeQuotaCustomizeDiff returns a CustomizeDiffFunc which (when enabled via the `compute_quota` block within the
+// `features` block) determines the number of cores and Virtual Machines required by the plan, from the size in
+// `sizeField` multiplied by the number of instances in `instancesField` (or a single Virtual Machine when empty),
+// and returns an error when this would exceed the remaining Compute quota in the location.
+//
+// NOTE: this is best-effort and only considers this resource - when the available sizes or usages can't be
+// retrieved no check is performed.
+func computeQuotaCustomizeDiff(sizeField, instancesField string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		if meta.(*clients.Client).Features.ComputeQuota.PreflightCheck != features.ComputeQuotaPreflightCheckError {
+			return nil
+		}
+
+		isNewResource := d.Id() == ""
+		changed := isNewResource || d.HasChange(sizeField)
+		if instancesField != "" && d.HasChange(instancesField) {
+			changed = true
+		}
+		if !changed || !d.NewValueKnown(sizeField) || !d.NewValueKnown("location") {
+			return nil
+		}
+		if instancesField != "" && !d.NewValueKnown(instancesField) {
+			return nil
+		}
+
+		loc := location.Normalize(d.Get("location").(string))
+		if loc == "" {
+			return nil
+		}
+
+		sizes := availableVirtualMachineSizes(ctx, meta.(*clients.Client).Compute.VMSizesClient, loc)
+		if sizes == nil {
+			return nil
+		}
+
+		oldSizeRaw, newSizeRaw := d.GetChange(sizeField)
+		oldCount, newCount := 0, 1
+		if !isNewResource {
+			oldCount = 1
+		}
+		if instancesField != "" {
+			oldInstancesRaw, newInstancesRaw := d.GetChange(instancesField)
+			oldCount = oldInstancesRaw.(int)
+			newCount = newInstancesRaw.(int)
+		}
+
+		newCores, ok := virtualMachineSizeCores(*sizes, newSizeRaw.(string))
+		if !ok {
+			// an unknown size is surfaced by the size validation, so there's nothing to compare against here
+			return nil
+		}
+		oldCores, _ := virtualMachineSizeCores(*sizes, oldSizeRaw.(string))
+
+		requested := map[string]int64{
+			"cores":           int64(newCount*newCores - oldCount*oldCores),
+			"virtualMachines": int64(newCount - oldCount),
+		}
+		if instancesField != "" && isNewResource {
+			requested["virtualMachineScaleSets"] = 1
+		}
+
+		usages, err := listComputeUsages(ctx, meta.(*clients.Client).Compute.UsageClient, loc)
+		if err != nil {
+			log.Printf("[DEBUG] %+v. The Compute quota pre-flight check will be skipped", err)
+			return nil
+		}
+
+		exceeded := computeQuotaExceeded(usages, requested)
+		if len(exceeded) == 0 {
+			return nil
+		}
+
+		return fmt.Errorf("the plan would exceed the remaining Compute quota in location %q: %s", loc, strings.Join(exceeded, "; "))
+	}
+}
+
+// computeQuotaExceeded returns a description of each usage which the requested amounts would exceed
+func computeQuotaExceeded(usages []compute.Usage, requested map[string]int64) []string {
+	exceeded := make([]string, 0)
+	for _, usage := range usages {
+		if usage.Name == nil || usage.Name.Value == nil {
+			continue
+		}
+
+		amount := int64(0)
+		for name, v := range requested {
+			if strings.EqualFold(name, *usage.Name.Value) {
+				amount = v
+				break
+			}
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		currentValue, limit := computeUsageValues(usage)
+		remaining := limit - currentValue
+		if amount <= remaining {
+			continue
+		}
+
+		name := *usage.Name.Value
+		if usage.Name.LocalizedValue != nil && *usage.Name.LocalizedValue != "" {
+			name = *usage.Name.LocalizedValue
+		}
+		if remaining < 0 {
+			remaining = 0
+		}
+		exceeded = append(exceeded, fmt.Sprintf("%s requires %d but only %d of %d remain", name, amount, remaining, limit))
+	}
+
+	sort.Strings(exceeded)
+	return exceeded
+}
+
+func virtualMachineSizeCores(sizes []compute.VirtualMachineSize, size string) (int, bool) {
+	if size == "" {
+		return 0, false
+	}
+
+	for _, v := range sizes {
+		if v.Name != nil && strings.EqualFold(*v.Name, size) && v.NumberOfCores != nil {
+			return int(*v.NumberOfCores), true
+		}
+	}
+
+	return 0, false
+}
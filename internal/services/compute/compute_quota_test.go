@@ -0,0 +1,141 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestComputeQuotaExceeded(t *testing.T) {
+	usages := []compute.Usage{
+		{
+			Name: &compute.UsageName{
+				Value:          utils.String("cores"),
+				LocalizedValue: utils.String("Total Regional vCPUs"),
+			},
+			CurrentValue: utils.Int32(90),
+			Limit:        utils.Int64(100),
+		},
+		{
+			Name: &compute.UsageName{
+				Value: utils.String("virtualMachines"),
+			},
+			CurrentValue: utils.Int32(10),
+			Limit:        utils.Int64(10),
+		},
+		{
+			Name: &compute.UsageName{
+				Value:          utils.String("availabilitySets"),
+				LocalizedValue: utils.String("Availability Sets"),
+			},
+			CurrentValue: utils.Int32(0),
+			Limit:        utils.Int64(0),
+		},
+	}
+
+	testCases := []struct {
+		Name      string
+		Requested map[string]int64
+		Expected  []string
+	}{
+		{
+			Name: "Within Quota",
+			Requested: map[string]int64{
+				"cores": 10,
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "Releasing Capacity",
+			Requested: map[string]int64{
+				"cores":           -4,
+				"virtualMachines": 0,
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "Cores Exceeded",
+			Requested: map[string]int64{
+				"cores": 12,
+			},
+			Expected: []string{
+				"Total Regional vCPUs requires 12 but only 10 of 100 remain",
+			},
+		},
+		{
+			Name: "Multiple Exceeded",
+			Requested: map[string]int64{
+				"cores":           16,
+				"virtualMachines": 2,
+			},
+			Expected: []string{
+				"Total Regional vCPUs requires 16 but only 10 of 100 remain",
+				"virtualMachines requires 2 but only 0 of 10 remain",
+			},
+		},
+		{
+			Name: "Usage Not Returned",
+			Requested: map[string]int64{
+				"virtualMachineScaleSets": 1,
+			},
+			Expected: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+		actual := computeQuotaExceeded(usages, testCase.Requested)
+		if !reflect.DeepEqual(actual, testCase.Expected) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestVirtualMachineSizeCores(t *testing.T) {
+	sizes := []compute.VirtualMachineSize{
+		{
+			Name:          utils.String("Standard_F2"),
+			NumberOfCores: utils.Int32(2),
+		},
+		{
+			Name: utils.String("Standard_F4"),
+		},
+	}
+
+	testCases := []struct {
+		Size          string
+		ExpectedCores int
+		ExpectedFound bool
+	}{
+		{
+			Size:          "Standard_F2",
+			ExpectedCores: 2,
+			ExpectedFound: true,
+		},
+		{
+			Size:          "standard_f2",
+			ExpectedCores: 2,
+			ExpectedFound: true,
+		},
+		{
+			// no cores returned
+			Size: "Standard_F4",
+		},
+		{
+			Size: "Standard_F8",
+		},
+		{
+			Size: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Size)
+		cores, found := virtualMachineSizeCores(sizes, testCase.Size)
+		if cores != testCase.ExpectedCores || found != testCase.ExpectedFound {
+			t.Fatalf("Expected %d / %t but got %d / %t", testCase.ExpectedCores, testCase.ExpectedFound, cores, found)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func computeUsageDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: computeUsageDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"location": commonschema.Location(),
+
+			"usages": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"localized_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"current_value": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"limit": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"remaining": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"unit": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func computeUsageDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.UsageClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	loc := location.Normalize(d.Get("location").(string))
+
+	usages, err := listComputeUsages(ctx, client, loc)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("location", loc)
+	if err := d.Set("usages", flattenComputeUsages(usages)); err != nil {
+		return fmt.Errorf("setting `usages`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenComputeUsages(input []compute.Usage) []interface{} {
+	output := make([]interface{}, 0)
+	for _, v := range input {
+		name := ""
+		localizedName := ""
+		if v.Name != nil {
+			if v.Name.Value != nil {
+				name = *v.Name.Value
+			}
+			if v.Name.LocalizedValue != nil {
+				localizedName = *v.Name.LocalizedValue
+			}
+		}
+
+		currentValue, limit := computeUsageValues(v)
+		unit := ""
+		if v.Unit != nil {
+			unit = *v.Unit
+		}
+
+		output = append(output, map[string]interface{}{
+			"name":           name,
+			"localized_name": localizedName,
+			"current_value":  int(currentValue),
+			"limit":          int(limit),
+			"remaining":      int(limit - currentValue),
+			"unit":           unit,
+		})
+	}
+	return output
+}
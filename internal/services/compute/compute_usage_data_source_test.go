@@ -0,0 +1,41 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type ComputeUsageDataSource struct{}
+
+func TestAccComputeUsageDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_compute_usage", "test")
+	r := ComputeUsageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("usages.#").Exists(),
+				check.That(data.ResourceName).Key("usages.0.name").Exists(),
+				check.That(data.ResourceName).Key("usages.0.current_value").Exists(),
+				check.That(data.ResourceName).Key("usages.0.limit").Exists(),
+				check.That(data.ResourceName).Key("usages.0.remaining").Exists(),
+			),
+		},
+	})
+}
+
+func (ComputeUsageDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_compute_usage" "test" {
+  location = "%s"
+}
+`, data.Locations.Primary)
+}
@@ -2,6 +2,7 @@ package compute
 
 import (
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
 )
 
@@ -33,3 +34,19 @@ func flattenSubResourcesToIDs(input *[]compute.SubResource) []interface{} {
 
 	return ids
 }
+
+// imageVersionLessThan compares two Image versions semantically, versions which can't be parsed are
+// compared lexically and sorted before any which can
+func imageVersionLessThan(first, second string) bool {
+	firstVersion, firstErr := version.NewVersion(first)
+	secondVersion, secondErr := version.NewVersion(second)
+	switch {
+	case firstErr != nil && secondErr != nil:
+		return first < second
+	case firstErr != nil:
+		return true
+	case secondErr != nil:
+		return false
+	}
+	return firstVersion.LessThan(secondVersion)
+}
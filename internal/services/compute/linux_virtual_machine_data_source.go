@@ -0,0 +1,187 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func linuxVirtualMachineDataSource() *pluginsdk.Resource {
+	s := virtualMachineDataSourceSchema()
+
+	s["admin_username"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Computed: true,
+	}
+
+	s["admin_ssh_key"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"public_key": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"username": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	s["allow_extension_operations"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeBool,
+		Computed: true,
+	}
+
+	s["boot_diagnostics"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"storage_account_uri": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	s["computer_name"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Computed: true,
+	}
+
+	s["disable_password_authentication"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeBool,
+		Computed: true,
+	}
+
+	s["provision_vm_agent"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeBool,
+		Computed: true,
+	}
+
+	s["source_image_id"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeString,
+		Computed: true,
+	}
+
+	s["source_image_reference"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"publisher": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"offer": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"sku": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"version": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	return &pluginsdk.Resource{
+		Read: linuxVirtualMachineDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: s,
+	}
+}
+
+func linuxVirtualMachineDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewVirtualMachineID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, compute.InstanceView)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	if props := resp.VirtualMachineProperties; props != nil && props.StorageProfile != nil && props.StorageProfile.OsDisk != nil {
+		if osType := props.StorageProfile.OsDisk.OsType; osType != compute.Linux {
+			return fmt.Errorf("%s is not a Linux Virtual Machine (OS Type %q)", id, string(osType))
+		}
+	}
+
+	d.SetId(id.ID())
+
+	if err := setVirtualMachineDataSourceProperties(ctx, d, meta, resp); err != nil {
+		return fmt.Errorf("setting properties for %s: %+v", id, err)
+	}
+
+	props := *resp.VirtualMachineProperties
+
+	if err := d.Set("boot_diagnostics", flattenBootDiagnostics(props.DiagnosticsProfile)); err != nil {
+		return fmt.Errorf("setting `boot_diagnostics`: %+v", err)
+	}
+
+	sshKeys := make([]interface{}, 0)
+	if profile := props.OsProfile; profile != nil {
+		d.Set("admin_username", profile.AdminUsername)
+		d.Set("allow_extension_operations", profile.AllowExtensionOperations)
+		d.Set("computer_name", profile.ComputerName)
+
+		if config := profile.LinuxConfiguration; config != nil {
+			d.Set("disable_password_authentication", config.DisablePasswordAuthentication)
+			d.Set("provision_vm_agent", config.ProvisionVMAgent)
+
+			flattenedSSHKeys, err := FlattenSSHKeys(config.SSH)
+			if err != nil {
+				return fmt.Errorf("flattening `admin_ssh_key`: %+v", err)
+			}
+			sshKeys = *flattenedSSHKeys
+		}
+	}
+	if err := d.Set("admin_ssh_key", sshKeys); err != nil {
+		return fmt.Errorf("setting `admin_ssh_key`: %+v", err)
+	}
+
+	if profile := props.StorageProfile; profile != nil {
+		var storageImageId string
+		if profile.ImageReference != nil && profile.ImageReference.ID != nil {
+			storageImageId = *profile.ImageReference.ID
+		}
+		d.Set("source_image_id", storageImageId)
+
+		if err := d.Set("source_image_reference", flattenSourceImageReference(profile.ImageReference)); err != nil {
+			return fmt.Errorf("setting `source_image_reference`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
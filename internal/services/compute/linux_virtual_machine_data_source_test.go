@@ -0,0 +1,44 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type LinuxVirtualMachineDataSource struct{}
+
+func TestAccLinuxVirtualMachineDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("size").HasValue("Standard_F2"),
+				check.That(data.ResourceName).Key("admin_username").HasValue("adminuser"),
+				check.That(data.ResourceName).Key("admin_ssh_key.#").HasValue("1"),
+				check.That(data.ResourceName).Key("power_state").HasValue("running"),
+				check.That(data.ResourceName).Key("network_interface_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("private_ip_address").Exists(),
+				check.That(data.ResourceName).Key("os_disk.0.storage_account_type").HasValue("Standard_LRS"),
+				check.That(data.ResourceName).Key("source_image_reference.0.offer").HasValue("UbuntuServer"),
+				check.That(data.ResourceName).Key("virtual_machine_id").Exists(),
+			),
+		},
+	})
+}
+
+func (LinuxVirtualMachineDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_linux_virtual_machine" "test" {
+  name                = azurestack_linux_virtual_machine.test.name
+  resource_group_name = azurestack_linux_virtual_machine.test.resource_group_name
+}
+`, LinuxVirtualMachineResource{}.authSSH(data))
+}
@@ -45,6 +45,12 @@ func linuxVirtualMachine() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(45 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			virtualMachineSizeCustomizeDiff("size", ""),
+			computeQuotaCustomizeDiff("size", ""),
+			sshKeysCustomizeDiff("admin_ssh_key"),
+		),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -337,7 +343,10 @@ func linuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	}
 
 	sshKeysRaw := d.Get("admin_ssh_key").(*pluginsdk.Set).List()
-	sshKeys := ExpandSSHKeys(sshKeysRaw)
+	sshKeys, err := expandSSHKeysWithReferences(ctx, meta.(*clients.Client).Compute.SSHPublicKeysClient, sshKeysRaw)
+	if err != nil {
+		return fmt.Errorf("expanding `admin_ssh_key`: %+v", err)
+	}
 
 	params := compute.VirtualMachine{
 		Name:     utils.String(id.Name),
@@ -564,7 +573,8 @@ func linuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}) error
 			if err != nil {
 				return fmt.Errorf("flattening `admin_ssh_key`: %+v", err)
 			}
-			if err := d.Set("admin_ssh_key", pluginsdk.NewSet(SSHKeySchemaHash, *flattenedSSHKeys)); err != nil {
+			existingSSHKeys := d.Get("admin_ssh_key").(*pluginsdk.Set).List()
+			if err := d.Set("admin_ssh_key", pluginsdk.NewSet(SSHKeySchemaHash, flattenSSHKeysWithReferences(*flattenedSSHKeys, existingSSHKeys))); err != nil {
 				return fmt.Errorf("setting `admin_ssh_key`: %+v", err)
 			}
 		}
@@ -1031,12 +1041,9 @@ func linuxVirtualMachineDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 			// https://docs.microsoft.com/en-us/azure/virtual-machines/states-lifecycle
 			log.Printf("[DEBUG] Powering Off Linux Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 			var skipShutdown *bool = nil
-			powerOffFuture, err := client.PowerOff(ctx, id.ResourceGroup, id.Name, skipShutdown)
-			if err != nil {
-				return fmt.Errorf("powering off Linux Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
-			}
-			if err := powerOffFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
-				return fmt.Errorf("waiting for power off of Linux Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+			description := fmt.Sprintf("Linux Virtual Machine %q (Resource Group %q)", id.Name, id.ResourceGroup)
+			if err := virtualMachinePowerOff(ctx, client, *id, skipShutdown, description); err != nil {
+				return err
 			}
 			log.Printf("[DEBUG] Powered Off Linux Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
 		}
@@ -134,6 +134,22 @@ resource "azurestack_linux_virtual_machine" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func TestAccLinuxVirtualMachine_authSSHPublicKeyResource(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.authSSHPublicKeyResource(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("admin_ssh_key.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("admin_ssh_key"),
+	})
+}
+
 func (r LinuxVirtualMachineResource) authSSH(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -168,6 +184,47 @@ resource "azurestack_linux_virtual_machine" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func (r LinuxVirtualMachineResource) authSSHPublicKeyResource(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_ssh_public_key" "test" {
+  name                = "acctestsshkey-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  public_key          = local.first_public_key
+}
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_F2"
+  admin_username      = "adminuser"
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username          = "adminuser"
+    ssh_public_key_id = azurestack_ssh_public_key.test.id
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
 func (r LinuxVirtualMachineResource) authSSHMultiple(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
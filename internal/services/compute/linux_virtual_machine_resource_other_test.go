@@ -0,0 +1,57 @@
+package compute_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+)
+
+func TestAccLinuxVirtualMachine_otherSizeUnavailable(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine", "test")
+	r := LinuxVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.otherSize(data, "Standard_F2_v9"),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("is not available in location"),
+		},
+	})
+}
+
+// the location is specified directly, since the size is only validated once the location is known
+func (r LinuxVirtualMachineResource) otherSize(data acceptance.TestData, size string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = "%s"
+  size                = "%s"
+  admin_username      = "adminuser"
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, r.template(data), data.RandomInteger, data.Locations.Primary, size)
+}
@@ -0,0 +1,74 @@
+package compute_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+)
+
+func TestAccLinuxVirtualMachineScaleSet_quotaPreflightCheckExceeded(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
+	r := LinuxVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.quotaPreflightCheck(data, 5000),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("the plan would exceed the remaining Compute quota"),
+		},
+	})
+}
+
+// the location is specified directly, since the quota is only checked once the location is known
+func (r LinuxVirtualMachineScaleSetResource) quotaPreflightCheck(data acceptance.TestData, instances int) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {
+    compute_quota {
+      preflight_check = "Error"
+    }
+  }
+}
+
+%s
+
+resource "azurestack_linux_virtual_machine_scale_set" "test" {
+  name                = "acctestvmss-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = "%s"
+  sku                 = "Standard_F2"
+  instances           = %d
+  admin_username      = "adminuser"
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = azurestack_subnet.test.id
+    }
+  }
+}
+`, r.template(data), data.RandomInteger, data.Locations.Primary, instances)
+}
@@ -40,6 +40,13 @@ func resourceLinuxVirtualMachineScaleSet() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(time.Minute * 60),
 		},
 
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			virtualMachineSizeCustomizeDiff("sku", "data_disk"),
+			computeQuotaCustomizeDiff("sku", "instances"),
+			sshKeysCustomizeDiff("admin_ssh_key"),
+			virtualMachineScaleSetRollingUpgradePolicyCustomizeDiff,
+		),
+
 		// TODO: exposing requireGuestProvisionSignal once it's available
 		// https://github.com/Azure/azure-rest-api-specs/pull/7246
 
@@ -168,6 +175,8 @@ func resourceLinuxVirtualMachineScaleSet() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"rolling_upgrade_policy": VirtualMachineScaleSetRollingUpgradePolicySchema(),
+
 			"secret": linuxSecretSchema(),
 
 			"single_placement_group": {
@@ -280,7 +289,10 @@ func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta i
 	}
 
 	sshKeysRaw := d.Get("admin_ssh_key").(*pluginsdk.Set).List()
-	sshKeys := ExpandSSHKeys(sshKeysRaw)
+	sshKeys, err := expandSSHKeysWithReferences(ctx, meta.(*clients.Client).Compute.SSHPublicKeysClient, sshKeysRaw)
+	if err != nil {
+		return fmt.Errorf("expanding `admin_ssh_key`: %+v", err)
+	}
 
 	healthProbeId := d.Get("health_probe_id").(string)
 	upgradeMode := compute.UpgradeMode(d.Get("upgrade_mode").(string))
@@ -291,6 +303,9 @@ func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta i
 		return fmt.Errorf("an `automatic_os_upgrade_policy` block cannot be specified when `upgrade_mode` is not set to `Automatic`")
 	}
 
+	rollingUpgradePolicyRaw := d.Get("rolling_upgrade_policy").([]interface{})
+	rollingUpgradePolicy := ExpandVirtualMachineScaleSetRollingUpgradePolicy(rollingUpgradePolicyRaw)
+
 	secretsRaw := d.Get("secret").([]interface{})
 	secrets := expandLinuxSecrets(secretsRaw)
 
@@ -318,6 +333,7 @@ func resourceLinuxVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta i
 	upgradePolicy := compute.UpgradePolicy{
 		Mode:                     upgradeMode,
 		AutomaticOSUpgradePolicy: automaticOSUpgradePolicy,
+		RollingUpgradePolicy:     rollingUpgradePolicy,
 	}
 
 	virtualMachineProfile := compute.VirtualMachineScaleSetVMProfile{
@@ -443,6 +459,7 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 	}
 
 	updateInstances := false
+	updateExtensions := false
 
 	// retrieve
 	// Upgrading to the 2021-07-01 exposed a new expand parameter to the GET method
@@ -483,7 +500,7 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 		}
 	}
 
-	if d.HasChange("automatic_os_upgrade_policy") {
+	if d.HasChange("automatic_os_upgrade_policy") || d.HasChange("rolling_upgrade_policy") {
 		upgradePolicy := compute.UpgradePolicy{}
 		if existing.VirtualMachineScaleSetProperties.UpgradePolicy == nil {
 			upgradePolicy = compute.UpgradePolicy{
@@ -503,6 +520,11 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 			}
 		}
 
+		if d.HasChange("rolling_upgrade_policy") {
+			rollingRaw := d.Get("rolling_upgrade_policy").([]interface{})
+			upgradePolicy.RollingUpgradePolicy = ExpandVirtualMachineScaleSetRollingUpgradePolicy(rollingRaw)
+		}
+
 		updateProps.UpgradePolicy = &upgradePolicy
 	}
 
@@ -518,7 +540,10 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 
 			if d.HasChange("admin_ssh_key") {
 				sshKeysRaw := d.Get("admin_ssh_key").(*pluginsdk.Set).List()
-				sshKeys := ExpandSSHKeys(sshKeysRaw)
+				sshKeys, err := expandSSHKeysWithReferences(ctx, meta.(*clients.Client).Compute.SSHPublicKeysClient, sshKeysRaw)
+				if err != nil {
+					return fmt.Errorf("expanding `admin_ssh_key`: %+v", err)
+				}
 				linuxConfig.SSH = &compute.SSHConfiguration{
 					PublicKeys: &sshKeys,
 				}
@@ -679,6 +704,7 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 
 	if d.HasChanges("extension") {
 		updateInstances = true
+		updateExtensions = true
 
 		extensionProfile, _, err := expandVirtualMachineScaleSetExtensions(d.Get("extension").(*pluginsdk.Set).List())
 		if err != nil {
@@ -695,6 +721,7 @@ func resourceLinuxVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta i
 
 	metaData := virtualMachineScaleSetUpdateMetaData{
 		AutomaticOSUpgradeIsEnabled: automaticOSUpgradeIsEnabled,
+		UpdateExtensions:            updateExtensions,
 		UpdateInstances:             updateInstances,
 		Client:                      meta.(*clients.Client).Compute,
 		Existing:                    existing,
@@ -818,7 +845,8 @@ func resourceLinuxVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta int
 				if err != nil {
 					return fmt.Errorf("flattening `admin_ssh_key`: %+v", err)
 				}
-				if err := d.Set("admin_ssh_key", pluginsdk.NewSet(SSHKeySchemaHash, *flattenedSshKeys)); err != nil {
+				existingSshKeys := d.Get("admin_ssh_key").(*pluginsdk.Set).List()
+				if err := d.Set("admin_ssh_key", pluginsdk.NewSet(SSHKeySchemaHash, flattenSSHKeysWithReferences(*flattenedSshKeys, existingSshKeys))); err != nil {
 					return fmt.Errorf("setting `admin_ssh_key`: %+v", err)
 				}
 			}
@@ -865,6 +893,11 @@ func resourceLinuxVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta int
 		if err := d.Set("automatic_os_upgrade_policy", flattenedAutomatic); err != nil {
 			return fmt.Errorf("setting `automatic_os_upgrade_policy`: %+v", err)
 		}
+
+		flattenedRolling := FlattenVirtualMachineScaleSetRollingUpgradePolicy(policy.RollingUpgradePolicy)
+		if err := d.Set("rolling_upgrade_policy", flattenedRolling); err != nil {
+			return fmt.Errorf("setting `rolling_upgrade_policy`: %+v", err)
+		}
 	}
 
 	return tags.FlattenAndSet(d, resp.Tags)
@@ -0,0 +1,199 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+func TestAccLinuxVirtualMachineScaleSet_upgradePolicyRolling(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
+	r := LinuxVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.upgradePolicyRolling(data, 20, "PT0S", "echo $HOSTNAME"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("upgrade_mode").HasValue("Rolling"),
+				check.That(data.ResourceName).Key("rolling_upgrade_policy.0.max_batch_instance_percent").HasValue("20"),
+			),
+		},
+		data.ImportStep("admin_password"),
+		{
+			Config: r.upgradePolicyRolling(data, 50, "PT1M", "echo $HOSTNAME"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("rolling_upgrade_policy.0.max_batch_instance_percent").HasValue("50"),
+				check.That(data.ResourceName).Key("rolling_upgrade_policy.0.pause_time_between_batches").HasValue("PT1M"),
+			),
+		},
+		data.ImportStep("admin_password"),
+	})
+}
+
+func TestAccLinuxVirtualMachineScaleSet_upgradePolicyRollingExtensionUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
+	r := LinuxVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.upgradePolicyRolling(data, 20, "PT0S", "echo $HOSTNAME"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password"),
+		{
+			Config: r.upgradePolicyRolling(data, 20, "PT0S", "echo $PATH"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("admin_password"),
+	})
+}
+
+func TestAccLinuxVirtualMachineScaleSet_upgradePolicyRollingDefault(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_linux_virtual_machine_scale_set", "test")
+	r := LinuxVirtualMachineScaleSetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.upgradePolicyRollingDefault(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("upgrade_mode").HasValue("Rolling"),
+				check.That(data.ResourceName).Key("rolling_upgrade_policy.#").HasValue("1"),
+			),
+		},
+		{
+			// the Rolling Upgrade Policy defaulted by Azure mustn't cause a diff
+			Config:   r.upgradePolicyRollingDefault(data),
+			PlanOnly: true,
+		},
+		data.ImportStep("admin_password"),
+	})
+}
+
+func (r LinuxVirtualMachineScaleSetResource) upgradePolicyRolling(data acceptance.TestData, maxBatchInstancePercent int, pauseTimeBetweenBatches, command string) string {
+	rollingUpgradePolicy := fmt.Sprintf(`
+  rolling_upgrade_policy {
+    max_batch_instance_percent              = %d
+    max_unhealthy_instance_percent          = 20
+    max_unhealthy_upgraded_instance_percent = 20
+    pause_time_between_batches              = "%s"
+  }
+`, maxBatchInstancePercent, pauseTimeBetweenBatches)
+	return r.upgradePolicyRollingTemplate(data, rollingUpgradePolicy, command)
+}
+
+func (r LinuxVirtualMachineScaleSetResource) upgradePolicyRollingDefault(data acceptance.TestData) string {
+	return r.upgradePolicyRollingTemplate(data, "", "echo $HOSTNAME")
+}
+
+func (r LinuxVirtualMachineScaleSetResource) upgradePolicyRollingTemplate(data acceptance.TestData, rollingUpgradePolicy, command string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_public_ip" "test" {
+  name                = "test-ip-%[2]d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  allocation_method   = "Static"
+}
+
+resource "azurestack_lb" "test" {
+  name                = "acctestlb-%[2]d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  frontend_ip_configuration {
+    name                 = "internal"
+    public_ip_address_id = azurestack_public_ip.test.id
+  }
+}
+
+resource "azurestack_lb_backend_address_pool" "test" {
+  name            = "test"
+  loadbalancer_id = azurestack_lb.test.id
+}
+
+resource "azurestack_lb_probe" "test" {
+  name                = "acctest-lb-probe"
+  resource_group_name = azurestack_resource_group.test.name
+  loadbalancer_id     = azurestack_lb.test.id
+  port                = 22
+}
+
+resource "azurestack_lb_rule" "test" {
+  name                           = "AccTestLBRule"
+  resource_group_name            = azurestack_resource_group.test.name
+  loadbalancer_id                = azurestack_lb.test.id
+  probe_id                       = azurestack_lb_probe.test.id
+  backend_address_pool_id        = azurestack_lb_backend_address_pool.test.id
+  frontend_ip_configuration_name = "internal"
+  protocol                       = "Tcp"
+  frontend_port                  = 22
+  backend_port                   = 22
+}
+
+resource "azurestack_linux_virtual_machine_scale_set" "test" {
+  name                = "acctestvmss-%[2]d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  sku                 = "Standard_F2"
+  instances           = 2
+  admin_username      = "adminuser"
+  admin_password      = "P@ssword1234!"
+  health_probe_id     = azurestack_lb_probe.test.id
+  upgrade_mode        = "Rolling"
+
+  disable_password_authentication = false
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name                                   = "internal"
+      primary                                = true
+      subnet_id                              = azurestack_subnet.test.id
+      load_balancer_backend_address_pool_ids = [azurestack_lb_backend_address_pool.test.id]
+    }
+  }
+%[3]s
+  extension {
+    name                       = "CustomScript"
+    publisher                  = "Microsoft.Azure.Extensions"
+    type                       = "CustomScript"
+    type_handler_version       = "2.0"
+    auto_upgrade_minor_version = true
+
+    settings = jsonencode({
+      "commandToExecute" = "%[4]s"
+    })
+  }
+
+  depends_on = [azurestack_lb_rule.test]
+}
+`, r.template(data), data.RandomInteger, rollingUpgradePolicy, command)
+}
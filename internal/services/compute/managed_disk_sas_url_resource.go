@@ -0,0 +1,149 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+// managedDiskSasUrl grants time-limited read access to a Managed Disk. The SAS URL
+// can't be retrieved again once granted, so the resource is always recreated rather
+// than imported or updated.
+func managedDiskSasUrl() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: managedDiskSasUrlCreate,
+		Read:   managedDiskSasUrlRead,
+		Delete: managedDiskSasUrlDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"managed_disk_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ManagedDiskID,
+			},
+
+			"duration_in_seconds": {
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(30, math.MaxInt32),
+			},
+
+			"sas_url": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func managedDiskSasUrlCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DisksClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDiskID(d.Get("managed_disk_id").(string))
+	if err != nil {
+		return err
+	}
+
+	grantAccessData := compute.GrantAccessData{
+		Access:            compute.Read,
+		DurationInSeconds: utils.Int32(int32(d.Get("duration_in_seconds").(int))),
+	}
+
+	future, err := client.GrantAccess(ctx, id.ResourceGroup, id.DiskName, grantAccessData)
+	if err != nil {
+		return fmt.Errorf("granting access to %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for access to be granted to %s: %+v", *id, err)
+	}
+
+	result, err := future.Result(*client)
+	if err != nil {
+		return fmt.Errorf("retrieving SAS URL for %s: %+v", *id, err)
+	}
+	if result.AccessSAS == nil {
+		return fmt.Errorf("retrieving SAS URL for %s: `accessSAS` was nil", *id)
+	}
+
+	d.SetId(id.ID())
+	d.Set("sas_url", result.AccessSAS)
+
+	return managedDiskSasUrlRead(d, meta)
+}
+
+func managedDiskSasUrlRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DisksClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDiskID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.DiskName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing SAS URL from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	// once the access has expired or been revoked the SAS URL is no longer usable
+	if props := resp.DiskProperties; props != nil && props.DiskState != compute.ActiveSAS {
+		log.Printf("[INFO] %s no longer has an active SAS URL - removing from state", *id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("managed_disk_id", id.ID())
+
+	return nil
+}
+
+func managedDiskSasUrlDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.DisksClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ManagedDiskID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.RevokeAccess(ctx, id.ResourceGroup, id.DiskName)
+	if err != nil {
+		return fmt.Errorf("revoking access to %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for access to be revoked from %s: %+v", *id, err)
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package compute
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
@@ -119,6 +121,72 @@ func retrieveIPAddressesForNIC(ctx context.Context, nicClient *network.Interface
 	}
 }
 
+// retrieveIPAddressesForScaleSet returns the Public and Private IP Addresses for each instance
+// within the specified Virtual Machine Scale Set, keyed by the (lower-cased) ID of the instance
+func retrieveIPAddressesForScaleSet(ctx context.Context, nicClient *network.InterfacesClient, pipClient *network.PublicIPAddressesClient, resourceGroup, scaleSetName string) (map[string]interfaceDetails, error) {
+	publicIPAddresses := make(map[string]string)
+	pipIterator, err := pipClient.ListVirtualMachineScaleSetPublicIPAddressesComplete(ctx, resourceGroup, scaleSetName)
+	if err != nil {
+		return nil, fmt.Errorf("listing Public IP Addresses: %+v", err)
+	}
+	for pipIterator.NotDone() {
+		pip := pipIterator.Value()
+		if pip.ID != nil && pip.PublicIPAddressPropertiesFormat != nil && pip.PublicIPAddressPropertiesFormat.IPAddress != nil {
+			publicIPAddresses[strings.ToLower(*pip.ID)] = *pip.PublicIPAddressPropertiesFormat.IPAddress
+		}
+
+		if err := pipIterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Public IP Addresses: %+v", err)
+		}
+	}
+
+	results := make(map[string]interfaceDetails)
+	nicIterator, err := nicClient.ListVirtualMachineScaleSetNetworkInterfacesComplete(ctx, resourceGroup, scaleSetName)
+	if err != nil {
+		return nil, fmt.Errorf("listing Network Interfaces: %+v", err)
+	}
+	for nicIterator.NotDone() {
+		nic := nicIterator.Value()
+		if props := nic.InterfacePropertiesFormat; props != nil && props.VirtualMachine != nil && props.VirtualMachine.ID != nil {
+			instanceId := strings.ToLower(*props.VirtualMachine.ID)
+			details := results[instanceId]
+			if details.privateIPAddresses == nil {
+				details.privateIPAddresses = make([]string, 0)
+			}
+			if details.publicIPAddresses == nil {
+				details.publicIPAddresses = make([]string, 0)
+			}
+
+			if props.IPConfigurations != nil {
+				for _, config := range *props.IPConfigurations {
+					configProps := config.InterfaceIPConfigurationPropertiesFormat
+					if configProps == nil {
+						continue
+					}
+
+					if configProps.PrivateIPAddress != nil {
+						details.privateIPAddresses = append(details.privateIPAddresses, *configProps.PrivateIPAddress)
+					}
+
+					if pip := configProps.PublicIPAddress; pip != nil && pip.ID != nil {
+						if ipAddress, ok := publicIPAddresses[strings.ToLower(*pip.ID)]; ok {
+							details.publicIPAddresses = append(details.publicIPAddresses, ipAddress)
+						}
+					}
+				}
+			}
+
+			results[instanceId] = details
+		}
+
+		if err := nicIterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("listing Network Interfaces: %+v", err)
+		}
+	}
+
+	return results, nil
+}
+
 // retrievePublicIPAddress returns the Public IP Address associated with an Azure Public IP
 func retrievePublicIPAddress(ctx context.Context, client *network.PublicIPAddressesClient, publicIPAddressID string) (*string, error) {
 	id, err := parse.PublicIpAddressID(publicIPAddressID)
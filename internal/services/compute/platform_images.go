@@ -0,0 +1,139 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-version"
+)
+
+type platformImage struct {
+	id        string
+	publisher string
+	offer     string
+	sku       string
+	version   string
+}
+
+func listPlatformImageVersions(ctx context.Context, client *compute.VirtualMachineImagesClient, loc, publisher, offer, sku, versionConstraint string) ([]platformImage, error) {
+	resp, err := client.List(ctx, loc, publisher, offer, sku, "", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing Platform Image versions for the sku %q (publisher %q / offer %q) in location %q: %+v", sku, publisher, offer, loc, err)
+	}
+
+	images := make([]platformImage, 0)
+	if resp.Value == nil {
+		return images, nil
+	}
+
+	for _, v := range *resp.Value {
+		if v.Name == nil {
+			continue
+		}
+
+		id := ""
+		if v.ID != nil {
+			id = *v.ID
+		}
+		images = append(images, platformImage{
+			id:        id,
+			publisher: publisher,
+			offer:     offer,
+			sku:       sku,
+			version:   *v.Name,
+		})
+	}
+
+	return filterPlatformImageVersions(images, versionConstraint), nil
+}
+
+// filterPlatformImageNames returns the names of the items matching the regular expression
+func filterPlatformImageNames(input *[]compute.VirtualMachineImageResource, regex *regexp.Regexp) []string {
+	names := make([]string, 0)
+	if input == nil {
+		return names
+	}
+
+	for _, v := range *input {
+		if v.Name == nil {
+			continue
+		}
+		if regex != nil && !regex.MatchString(*v.Name) {
+			continue
+		}
+		names = append(names, *v.Name)
+	}
+
+	return names
+}
+
+// filterPlatformImageVersions returns the versions of a single sku which match the constraint - where `latest`
+// returns only the newest version and an empty constraint returns all versions
+func filterPlatformImageVersions(input []platformImage, versionConstraint string) []platformImage {
+	if versionConstraint == "" || len(input) == 0 {
+		return input
+	}
+
+	if strings.EqualFold(versionConstraint, "latest") {
+		latest := input[0]
+		for _, v := range input[1:] {
+			if imageVersionLessThan(latest.version, v.version) {
+				latest = v
+			}
+		}
+		return []platformImage{latest}
+	}
+
+	// this has been validated, so the error can be ignored
+	constraint, _ := version.NewConstraint(versionConstraint)
+
+	output := make([]platformImage, 0)
+	for _, v := range input {
+		parsed, err := version.NewVersion(v.version)
+		if err != nil {
+			continue
+		}
+		if constraint.Check(parsed) {
+			output = append(output, v)
+		}
+	}
+
+	return output
+}
+
+// sortPlatformImages sorts the images by publisher, offer and sku - and then from the oldest to newest version,
+// such that the results are deterministic
+func sortPlatformImages(input []platformImage) {
+	sort.SliceStable(input, func(i, j int) bool {
+		first := input[i]
+		second := input[j]
+		if !strings.EqualFold(first.publisher, second.publisher) {
+			return strings.ToLower(first.publisher) < strings.ToLower(second.publisher)
+		}
+		if !strings.EqualFold(first.offer, second.offer) {
+			return strings.ToLower(first.offer) < strings.ToLower(second.offer)
+		}
+		if !strings.EqualFold(first.sku, second.sku) {
+			return strings.ToLower(first.sku) < strings.ToLower(second.sku)
+		}
+		return imageVersionLessThan(first.version, second.version)
+	})
+}
+
+func flattenPlatformImages(input []platformImage) []interface{} {
+	output := make([]interface{}, 0)
+	for _, v := range input {
+		output = append(output, map[string]interface{}{
+			"id":        v.id,
+			"publisher": v.publisher,
+			"offer":     v.offer,
+			"sku":       v.sku,
+			"version":   v.version,
+		})
+	}
+	return output
+}
@@ -0,0 +1,139 @@
+package compute
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func platformImagesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: platformImagesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"location": commonschema.Location(),
+
+			"publisher_regex": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"offer_regex": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"sku_regex": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			// either `latest` (the newest version of each sku) or a version constraint such as `~> 2022.1`
+			"version": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ImageVersionConstraint,
+			},
+
+			"images": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"publisher": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"offer": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"sku": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"version": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func platformImagesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMImageClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	loc := location.Normalize(d.Get("location").(string))
+
+	// these have been validated, so the errors can be ignored
+	publisherRegex, _ := regexp.Compile(d.Get("publisher_regex").(string))
+	offerRegex, _ := regexp.Compile(d.Get("offer_regex").(string))
+	skuRegex, _ := regexp.Compile(d.Get("sku_regex").(string))
+
+	publishers, err := client.ListPublishers(ctx, loc)
+	if err != nil {
+		return fmt.Errorf("listing Platform Image publishers in location %q: %+v", loc, err)
+	}
+
+	images := make([]platformImage, 0)
+	for _, publisher := range filterPlatformImageNames(publishers.Value, publisherRegex) {
+		offers, err := client.ListOffers(ctx, loc, publisher)
+		if err != nil {
+			return fmt.Errorf("listing Platform Image offers for the publisher %q in location %q: %+v", publisher, loc, err)
+		}
+
+		for _, offer := range filterPlatformImageNames(offers.Value, offerRegex) {
+			skus, err := client.ListSkus(ctx, loc, publisher, offer)
+			if err != nil {
+				return fmt.Errorf("listing Platform Image skus for the offer %q (publisher %q) in location %q: %+v", offer, publisher, loc, err)
+			}
+
+			for _, sku := range filterPlatformImageNames(skus.Value, skuRegex) {
+				versions, err := listPlatformImageVersions(ctx, client, loc, publisher, offer, sku, d.Get("version").(string))
+				if err != nil {
+					return err
+				}
+
+				images = append(images, versions...)
+			}
+		}
+	}
+
+	sortPlatformImages(images)
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("images", flattenPlatformImages(images)); err != nil {
+		return fmt.Errorf("setting `images`: %+v", err)
+	}
+
+	return nil
+}
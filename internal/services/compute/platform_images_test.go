@@ -0,0 +1,133 @@
+package compute
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestFilterPlatformImageNames(t *testing.T) {
+	input := &[]compute.VirtualMachineImageResource{
+		{Name: utils.String("16.04-LTS")},
+		{Name: utils.String("18.04-LTS")},
+		{},
+		{Name: utils.String("18.04-DAILY-LTS")},
+	}
+
+	testCases := []struct {
+		Name     string
+		Regex    string
+		Expected []string
+	}{
+		{
+			Name:     "All",
+			Regex:    "",
+			Expected: []string{"16.04-LTS", "18.04-LTS", "18.04-DAILY-LTS"},
+		},
+		{
+			Name:     "Prefix",
+			Regex:    "^18\\.04",
+			Expected: []string{"18.04-LTS", "18.04-DAILY-LTS"},
+		},
+		{
+			Name:     "Exact",
+			Regex:    "^18\\.04-LTS$",
+			Expected: []string{"18.04-LTS"},
+		},
+		{
+			Name:     "None",
+			Regex:    "^20\\.04",
+			Expected: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+		actual := filterPlatformImageNames(input, regexp.MustCompile(testCase.Regex))
+		if !reflect.DeepEqual(actual, testCase.Expected) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestFilterPlatformImageVersions(t *testing.T) {
+	input := []platformImage{
+		{version: "2022.1.15"},
+		{version: "2021.12.1"},
+		{version: "2022.2.3"},
+		{version: "2023.1.1"},
+	}
+
+	testCases := []struct {
+		Name       string
+		Constraint string
+		Expected   []string
+	}{
+		{
+			Name:       "All",
+			Constraint: "",
+			Expected:   []string{"2022.1.15", "2021.12.1", "2022.2.3", "2023.1.1"},
+		},
+		{
+			Name:       "Latest",
+			Constraint: "latest",
+			Expected:   []string{"2023.1.1"},
+		},
+		{
+			Name:       "Pessimistic Minor",
+			Constraint: "~> 2022.1",
+			Expected:   []string{"2022.1.15", "2022.2.3"},
+		},
+		{
+			Name:       "Pessimistic Patch",
+			Constraint: "~> 2022.1.0",
+			Expected:   []string{"2022.1.15"},
+		},
+		{
+			Name:       "Exact",
+			Constraint: "2021.12.1",
+			Expected:   []string{"2021.12.1"},
+		},
+		{
+			Name:       "None",
+			Constraint: "> 2024.0",
+			Expected:   []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+		actual := make([]string, 0)
+		for _, v := range filterPlatformImageVersions(input, testCase.Constraint) {
+			actual = append(actual, v.version)
+		}
+		if !reflect.DeepEqual(actual, testCase.Expected) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestSortPlatformImages(t *testing.T) {
+	input := []platformImage{
+		{publisher: "MicrosoftWindowsServer", offer: "WindowsServer", sku: "2019-Datacenter", version: "17763.1.2"},
+		{publisher: "Canonical", offer: "UbuntuServer", sku: "18.04-LTS", version: "18.04.202010140"},
+		{publisher: "canonical", offer: "UbuntuServer", sku: "16.04-LTS", version: "16.04.202007080"},
+		{publisher: "Canonical", offer: "UbuntuServer", sku: "18.04-LTS", version: "18.04.201912180"},
+		{publisher: "MicrosoftWindowsServer", offer: "WindowsServer", sku: "2019-Datacenter", version: "17763.10.2"},
+	}
+	expected := []platformImage{
+		{publisher: "canonical", offer: "UbuntuServer", sku: "16.04-LTS", version: "16.04.202007080"},
+		{publisher: "Canonical", offer: "UbuntuServer", sku: "18.04-LTS", version: "18.04.201912180"},
+		{publisher: "Canonical", offer: "UbuntuServer", sku: "18.04-LTS", version: "18.04.202010140"},
+		{publisher: "MicrosoftWindowsServer", offer: "WindowsServer", sku: "2019-Datacenter", version: "17763.1.2"},
+		{publisher: "MicrosoftWindowsServer", offer: "WindowsServer", sku: "2019-Datacenter", version: "17763.10.2"},
+	}
+
+	sortPlatformImages(input)
+	if !reflect.DeepEqual(input, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, input)
+	}
+}
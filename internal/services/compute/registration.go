@@ -21,28 +21,48 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurestack_availability_set": availabilitySetDataSource(),
-		"azurestack_managed_disk":     managedDiskDataSource(),
-		"azurestack_platform_image":   platformImageDataSource(),
-		"azurestack_image":            imageDataSource(),
+		"azurestack_availability_set":                    availabilitySetDataSource(),
+		"azurestack_managed_disk":                        managedDiskDataSource(),
+		"azurestack_platform_image":                      platformImageDataSource(),
+		"azurestack_platform_images":                     platformImagesDataSource(),
+		"azurestack_compute_usage":                       computeUsageDataSource(),
+		"azurestack_image":                               imageDataSource(),
+		"azurestack_linux_virtual_machine":               linuxVirtualMachineDataSource(),
+		"azurestack_snapshot":                            snapshotDataSource(),
+		"azurestack_ssh_public_key":                      sshPublicKeyDataSource(),
+		"azurestack_virtual_machine":                     virtualMachineDataSource(),
+		"azurestack_virtual_machine_boot_diagnostics":    virtualMachineBootDiagnosticsDataSource(),
+		"azurestack_virtual_machine_scale_set":           virtualMachineScaleSetDataSource(),
+		"azurestack_virtual_machine_extension_image":     virtualMachineExtensionImageDataSource(),
+		"azurestack_virtual_machine_scale_set_instances": virtualMachineScaleSetInstancesDataSource(),
+		"azurestack_virtual_machine_sizes":               virtualMachineSizesDataSource(),
 	}
 }
 
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	resources := map[string]*pluginsdk.Resource{
-		"azurestack_availability_set":                     availabilitySet(),
-		"azurestack_linux_virtual_machine":                linuxVirtualMachine(),
-		"azurestack_linux_virtual_machine_scale_set":      resourceLinuxVirtualMachineScaleSet(),
-		"azurestack_managed_disk":                         managedDisk(),
-		"azurestack_virtual_machine":                      virtualMachine(),
-		"azurestack_virtual_machine_data_disk_attachment": virtualMachineDataDiskAttachment(),
-		"azurestack_virtual_machine_extension":            virtualMachineExtension(),
-		"azurestack_virtual_machine_scale_set":            virtualMachineScaleSet(),
-		"azurestack_virtual_machine_scale_set_extension":  virtualMachineScaleSetExtension(),
-		"azurestack_image":                                image(),
-		"azurestack_windows_virtual_machine":              windowsVirtualMachine(),
-		"azurestack_windows_virtual_machine_scale_set":    resourceWindowsVirtualMachineScaleSet(),
+		"azurestack_availability_set":                        availabilitySet(),
+		"azurestack_linux_virtual_machine":                   linuxVirtualMachine(),
+		"azurestack_linux_virtual_machine_scale_set":         resourceLinuxVirtualMachineScaleSet(),
+		"azurestack_managed_disk":                            managedDisk(),
+		"azurestack_managed_disk_sas_url":                    managedDiskSasUrl(),
+		"azurestack_virtual_machine":                         virtualMachine(),
+		"azurestack_virtual_machine_data_disk_attachment":    virtualMachineDataDiskAttachment(),
+		"azurestack_virtual_machine_extension":               virtualMachineExtension(),
+		"azurestack_virtual_machine_image_capture":           virtualMachineImageCapture(),
+		"azurestack_virtual_machine_managed_disk_conversion": virtualMachineManagedDiskConversion(),
+		"azurestack_virtual_machine_power_state":             virtualMachinePowerState(),
+		"azurestack_virtual_machine_run_script":              virtualMachineRunScript(),
+		"azurestack_virtual_machine_scale_set":               virtualMachineScaleSet(),
+		"azurestack_virtual_machine_scale_set_extension":     virtualMachineScaleSetExtension(),
+		"azurestack_virtual_machine_scale_set_instance":      virtualMachineScaleSetInstance(),
+		"azurestack_image":                                   image(),
+		"azurestack_snapshot":                                snapshot(),
+		"azurestack_snapshot_sas_url":                        snapshotSasUrl(),
+		"azurestack_ssh_public_key":                          sshPublicKey(),
+		"azurestack_windows_virtual_machine":                 windowsVirtualMachine(),
+		"azurestack_windows_virtual_machine_scale_set":       resourceWindowsVirtualMachineScaleSet(),
 	}
 
 	return resources
@@ -0,0 +1,41 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type SnapshotDataSource struct{}
+
+func TestAccSnapshotDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_snapshot", "test")
+	r := SnapshotDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("name").Exists(),
+				check.That(data.ResourceName).Key("create_option").HasValue("Copy"),
+				check.That(data.ResourceName).Key("disk_size_gb").HasValue("10"),
+				check.That(data.ResourceName).Key("source_resource_id").Exists(),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("acctest"),
+			),
+		},
+	})
+}
+
+func (SnapshotDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_snapshot" "test" {
+  name                = azurestack_snapshot.test.name
+  resource_group_name = azurestack_snapshot.test.resource_group_name
+}
+`, SnapshotResource{}.fromManagedDisk(data))
+}
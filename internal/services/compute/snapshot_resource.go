@@ -0,0 +1,320 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func snapshot() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: snapshotCreate,
+		Read:   snapshotRead,
+		Update: snapshotUpdate,
+		Delete: snapshotDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.SnapshotID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"location": commonschema.Location(),
+
+			"resource_group_name": commonschema.ResourceGroupName(),
+
+			"create_option": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(compute.Copy),
+					string(compute.Import),
+				}, false),
+			},
+
+			"source_uri": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_resource_id": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.Any(
+					validate.ManagedDiskID,
+					validate.SnapshotID,
+				),
+			},
+
+			"storage_account_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: resourceid.ValidateResourceID,
+			},
+
+			"disk_size_gb": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ManagedDiskSizeGB,
+			},
+
+			"incremental_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"encryption": encryptionSettingsSchema(),
+
+			"os_type": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"time_created": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func snapshotCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewSnapshotID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurestack_snapshot", id.ID())
+	}
+
+	createOption := compute.DiskCreateOption(d.Get("create_option").(string))
+
+	props := &compute.SnapshotProperties{
+		CreationData: &compute.CreationData{
+			CreateOption: createOption,
+		},
+		Incremental: pointer.FromBool(d.Get("incremental_enabled").(bool)),
+	}
+
+	if createOption == compute.Import {
+		sourceUri := d.Get("source_uri").(string)
+		if sourceUri == "" {
+			return fmt.Errorf("`source_uri` must be specified when `create_option` is set to `Import`")
+		}
+
+		storageAccountId := d.Get("storage_account_id").(string)
+		if storageAccountId == "" {
+			return fmt.Errorf("`storage_account_id` must be specified when `create_option` is set to `Import`")
+		}
+
+		props.CreationData.SourceURI = pointer.FromString(sourceUri)
+		props.CreationData.StorageAccountID = pointer.FromString(storageAccountId)
+	}
+
+	if createOption == compute.Copy {
+		sourceResourceId := d.Get("source_resource_id").(string)
+		if sourceResourceId == "" {
+			return fmt.Errorf("`source_resource_id` must be specified when `create_option` is set to `Copy`")
+		}
+
+		props.CreationData.SourceResourceID = pointer.FromString(sourceResourceId)
+	}
+
+	if v, ok := d.GetOk("disk_size_gb"); ok {
+		props.DiskSizeGB = utils.Int32(int32(v.(int)))
+	}
+
+	if v, ok := d.GetOk("encryption"); ok {
+		encryptionSettings := v.([]interface{})
+		settings := encryptionSettings[0].(map[string]interface{})
+		props.EncryptionSettingsCollection = expandManagedDiskEncryptionSettings(settings)
+	}
+
+	parameters := compute.Snapshot{
+		Location:           pointer.FromString(location.Normalize(d.Get("location").(string))),
+		SnapshotProperties: props,
+		Tags:               tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters)
+	if err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation of %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	return snapshotRead(d, meta)
+}
+
+func snapshotUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	update := compute.SnapshotUpdate{
+		SnapshotUpdateProperties: &compute.SnapshotUpdateProperties{},
+	}
+
+	if d.HasChange("disk_size_gb") {
+		if old, new := d.GetChange("disk_size_gb"); new.(int) > old.(int) {
+			update.SnapshotUpdateProperties.DiskSizeGB = utils.Int32(int32(new.(int)))
+		} else {
+			return fmt.Errorf("- New size must be greater than original size. Shrinking snapshots is not supported on Azure")
+		}
+	}
+
+	if d.HasChange("encryption") {
+		update.SnapshotUpdateProperties.EncryptionSettingsCollection = &compute.EncryptionSettingsCollection{
+			Enabled: pointer.FromBool(false),
+		}
+		if v, ok := d.GetOk("encryption"); ok {
+			encryptionSettings := v.([]interface{})
+			settings := encryptionSettings[0].(map[string]interface{})
+			update.SnapshotUpdateProperties.EncryptionSettingsCollection = expandManagedDiskEncryptionSettings(settings)
+		}
+	}
+
+	if d.HasChange("tags") {
+		update.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
+	}
+
+	future, err := client.Update(ctx, id.ResourceGroup, id.Name, update)
+	if err != nil {
+		return fmt.Errorf("updating %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of %s: %+v", *id, err)
+	}
+
+	return snapshotRead(d, meta)
+}
+
+func snapshotRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+
+	if props := resp.SnapshotProperties; props != nil {
+		if data := props.CreationData; data != nil {
+			d.Set("create_option", string(data.CreateOption))
+			d.Set("source_uri", data.SourceURI)
+			d.Set("source_resource_id", data.SourceResourceID)
+			d.Set("storage_account_id", data.StorageAccountID)
+		}
+
+		d.Set("disk_size_gb", props.DiskSizeGB)
+		d.Set("incremental_enabled", utils.NormaliseNilableBool(props.Incremental))
+		d.Set("os_type", string(props.OsType))
+
+		timeCreated := ""
+		if props.TimeCreated != nil {
+			timeCreated = props.TimeCreated.Format(time.RFC3339)
+		}
+		d.Set("time_created", timeCreated)
+
+		if err := d.Set("encryption", flattenManagedDiskEncryptionSettings(props.EncryptionSettingsCollection)); err != nil {
+			return fmt.Errorf("setting `encryption`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func snapshotDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
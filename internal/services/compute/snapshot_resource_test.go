@@ -0,0 +1,199 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type SnapshotResource struct{}
+
+func TestAccSnapshot_fromManagedDisk(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromManagedDisk(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("disk_size_gb").HasValue("10"),
+				check.That(data.ResourceName).Key("time_created").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSnapshot_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromManagedDisk(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(data),
+			ExpectError: acceptance.RequiresImportError("azurestack_snapshot"),
+		},
+	})
+}
+
+func TestAccSnapshot_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromManagedDisk(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("disk_size_gb").HasValue("20"),
+				check.That(data.ResourceName).Key("tags.%").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSnapshot_managedDiskFromSnapshot(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot", "test")
+	r := SnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.managedDiskFromSnapshot(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurestack_managed_disk.restored").ExistsInAzure(ManagedDiskResource{}),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (SnapshotResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.SnapshotID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.SnapshotsClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.FromBool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (SnapshotResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
+func (r SnapshotResource) fromManagedDisk(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Copy"
+  source_resource_id  = azurestack_managed_disk.test.id
+
+  tags = {
+    environment = "acctest"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r SnapshotResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot" "import" {
+  name                = azurestack_snapshot.test.name
+  location            = azurestack_snapshot.test.location
+  resource_group_name = azurestack_snapshot.test.resource_group_name
+  create_option       = azurestack_snapshot.test.create_option
+  source_resource_id  = azurestack_snapshot.test.source_resource_id
+}
+`, r.fromManagedDisk(data))
+}
+
+func (r SnapshotResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot" "test" {
+  name                = "acctestss-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  create_option       = "Copy"
+  source_resource_id  = azurestack_managed_disk.test.id
+  disk_size_gb        = 20
+
+  tags = {
+    environment = "acctest"
+    cost-center = "ops"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r SnapshotResource) managedDiskFromSnapshot(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_managed_disk" "restored" {
+  name                 = "acctestmd2-%d"
+  location             = azurestack_resource_group.test.location
+  resource_group_name  = azurestack_resource_group.test.name
+  storage_account_type = "Standard_LRS"
+  create_option        = "Copy"
+  source_resource_id   = azurestack_snapshot.test.id
+  disk_size_gb         = "10"
+}
+`, r.fromManagedDisk(data), data.RandomInteger)
+}
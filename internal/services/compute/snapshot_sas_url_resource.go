@@ -0,0 +1,163 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+// snapshotSasUrl grants time-limited read access to a Snapshot, in the same way
+// managedDiskSasUrl does for Managed Disks.
+func snapshotSasUrl() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: snapshotSasUrlCreate,
+		Read:   snapshotSasUrlRead,
+		Delete: snapshotSasUrlDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"snapshot_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.SnapshotID,
+			},
+
+			"duration_in_seconds": {
+				Type:         pluginsdk.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(30, math.MaxInt32),
+			},
+
+			"sas_url": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"expiry": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func snapshotSasUrlCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Get("snapshot_id").(string))
+	if err != nil {
+		return err
+	}
+
+	duration := d.Get("duration_in_seconds").(int)
+	grantAccessData := compute.GrantAccessData{
+		Access:            compute.Read,
+		DurationInSeconds: utils.Int32(int32(duration)),
+	}
+	expiry := time.Now().Add(time.Duration(duration) * time.Second)
+
+	future, err := client.GrantAccess(ctx, id.ResourceGroup, id.Name, grantAccessData)
+	if err != nil {
+		return fmt.Errorf("granting access to %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for access to be granted to %s: %+v", *id, err)
+	}
+
+	result, err := future.Result(*client)
+	if err != nil {
+		return fmt.Errorf("retrieving SAS URL for %s: %+v", *id, err)
+	}
+	if result.AccessSAS == nil {
+		return fmt.Errorf("retrieving SAS URL for %s: `accessSAS` was nil", *id)
+	}
+
+	d.SetId(id.ID())
+	d.Set("sas_url", result.AccessSAS)
+	d.Set("expiry", expiry.UTC().Format(time.RFC3339))
+
+	return snapshotSasUrlRead(d, meta)
+}
+
+func snapshotSasUrlRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing SAS URL from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	// unlike Managed Disks, Snapshots don't expose whether access is still granted - so
+	// the SAS URL is removed from state once the duration it was granted for has passed
+	if v := d.Get("expiry").(string); v != "" {
+		expiry, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("parsing `expiry` %q: %+v", v, err)
+		}
+		if time.Now().After(expiry) {
+			log.Printf("[INFO] the SAS URL for %s has expired - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	d.Set("snapshot_id", id.ID())
+
+	return nil
+}
+
+func snapshotSasUrlDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SnapshotsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SnapshotID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.RevokeAccess(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("revoking access to %s: %+v", *id, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for access to be revoked from %s: %+v", *id, err)
+	}
+
+	return nil
+}
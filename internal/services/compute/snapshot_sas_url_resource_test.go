@@ -0,0 +1,57 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type SnapshotSasUrlResource struct{}
+
+func TestAccSnapshotSasUrl_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_snapshot_sas_url", "test")
+	r := SnapshotSasUrlResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sas_url").Exists(),
+				check.That(data.ResourceName).Key("expiry").Exists(),
+			),
+		},
+	})
+}
+
+func (SnapshotSasUrlResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.SnapshotID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.SnapshotsClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (SnapshotSasUrlResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_snapshot_sas_url" "test" {
+  snapshot_id         = azurestack_snapshot.test.id
+  duration_in_seconds = 300
+}
+`, SnapshotResource{}.fromManagedDisk(data))
+}
@@ -2,12 +2,15 @@ package compute
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
@@ -24,14 +27,24 @@ func SSHKeysSchema(isVirtualMachine bool) *pluginsdk.Schema {
 		Set:      SSHKeySchemaHash,
 		Elem: &pluginsdk.Resource{
 			Schema: map[string]*pluginsdk.Schema{
+				// exactly one of `public_key` or `ssh_public_key_id` must be specified - since the fields are within a
+				// Set this is validated by sshKeysCustomizeDiff. When `ssh_public_key_id` is specified `public_key` is computed
 				"public_key": {
 					Type:             pluginsdk.TypeString,
-					Required:         true,
+					Optional:         true,
+					Computed:         true,
 					ForceNew:         isVirtualMachine,
 					ValidateFunc:     validate.SSHKey,
 					DiffSuppressFunc: SSHKeyDiffSuppress,
 				},
 
+				"ssh_public_key_id": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ForceNew:     isVirtualMachine,
+					ValidateFunc: validate.SSHPublicKeyID,
+				},
+
 				"username": {
 					Type:         pluginsdk.TypeString,
 					Required:     true,
@@ -59,6 +72,87 @@ func ExpandSSHKeys(input []interface{}) []compute.SSHPublicKey {
 	return output
 }
 
+// sshKeysCustomizeDiff ensures that exactly one of `public_key` or `ssh_public_key_id` is specified within
+// each of the SSH Key blocks in `field` - which can't be done using `ExactlyOneOf` since these are within a Set.
+// The raw config is used since `public_key` is computed, and values which aren't known yet are skipped
+func sshKeysCustomizeDiff(field string) pluginsdk.CustomizeDiffFunc {
+	return func(_ context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		keys := config.GetAttr(field)
+		if keys.IsNull() || !keys.IsKnown() {
+			return nil
+		}
+
+		for it := keys.ElementIterator(); it.Next(); {
+			_, key := it.Element()
+			if key.IsNull() || !key.IsKnown() {
+				continue
+			}
+
+			publicKey := key.GetAttr("public_key")
+			sshPublicKeyId := key.GetAttr("ssh_public_key_id")
+			if !publicKey.IsKnown() || !sshPublicKeyId.IsKnown() {
+				continue
+			}
+
+			hasPublicKey := !publicKey.IsNull() && publicKey.AsString() != ""
+			hasSSHPublicKeyId := !sshPublicKeyId.IsNull() && sshPublicKeyId.AsString() != ""
+			if hasPublicKey == hasSSHPublicKeyId {
+				return fmt.Errorf("exactly one of `public_key` or `ssh_public_key_id` must be specified within each `%s` block", field)
+			}
+		}
+
+		return nil
+	}
+}
+
+// expandSSHKeysWithReferences expands the `admin_ssh_key` blocks, retrieving the Public Key for any
+// blocks which reference an SSH Public Key resource via `ssh_public_key_id`
+func expandSSHKeysWithReferences(ctx context.Context, client *compute.SSHPublicKeysClient, input []interface{}) ([]compute.SSHPublicKey, error) {
+	resolved := make([]interface{}, 0)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+
+		sshPublicKeyId := ""
+		if v, ok := raw["ssh_public_key_id"]; ok {
+			sshPublicKeyId = v.(string)
+		}
+		if sshPublicKeyId == "" {
+			if raw["public_key"].(string) == "" {
+				return nil, fmt.Errorf("one of `public_key` or `ssh_public_key_id` must be specified for the `admin_ssh_key` with the username %q", raw["username"].(string))
+			}
+
+			resolved = append(resolved, raw)
+			continue
+		}
+
+		id, err := parse.SSHPublicKeyID(sshPublicKeyId)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+		}
+		if resp.SSHPublicKeyResourceProperties == nil || resp.SSHPublicKeyResourceProperties.PublicKey == nil {
+			return nil, fmt.Errorf("retrieving %s: `properties.publicKey` was nil", *id)
+		}
+
+		resolved = append(resolved, map[string]interface{}{
+			"public_key": *resp.SSHPublicKeyResourceProperties.PublicKey,
+			"username":   raw["username"].(string),
+		})
+	}
+
+	return ExpandSSHKeys(resolved), nil
+}
+
 func FlattenSSHKeys(input *compute.SSHConfiguration) (*[]interface{}, error) {
 	if input == nil || input.PublicKeys == nil {
 		return &[]interface{}{}, nil
@@ -84,6 +178,56 @@ func FlattenSSHKeys(input *compute.SSHConfiguration) (*[]interface{}, error) {
 	return &output, nil
 }
 
+// flattenSSHKeysWithReferences populates `ssh_public_key_id` for the flattened `admin_ssh_key` blocks, since the API
+// only returns the Public Key - the reference is retained from the existing block with the same username and Public Key,
+// or from an existing block with the same username whose Public Key hasn't been resolved yet
+func flattenSSHKeysWithReferences(input []interface{}, existing []interface{}) []interface{} {
+	used := make(map[int]bool)
+
+	findReference := func(username, publicKey string) string {
+		normalisedKey, err := utils.NormalizeSSHKey(publicKey)
+		if err != nil {
+			return ""
+		}
+
+		for i, v := range existing {
+			raw := v.(map[string]interface{})
+			if used[i] || raw["username"].(string) != username || raw["public_key"].(string) == "" {
+				continue
+			}
+
+			if existingKey, err := utils.NormalizeSSHKey(raw["public_key"].(string)); err == nil && *existingKey == *normalisedKey {
+				used[i] = true
+				id, _ := raw["ssh_public_key_id"].(string)
+				return id
+			}
+		}
+
+		for i, v := range existing {
+			raw := v.(map[string]interface{})
+			if used[i] || raw["username"].(string) != username || raw["public_key"].(string) != "" {
+				continue
+			}
+
+			if id, _ := raw["ssh_public_key_id"].(string); id != "" {
+				used[i] = true
+				return id
+			}
+		}
+
+		return ""
+	}
+
+	output := make([]interface{}, 0)
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+		raw["ssh_public_key_id"] = findReference(raw["username"].(string), raw["public_key"].(string))
+		output = append(output, raw)
+	}
+
+	return output
+}
+
 // formatUsernameForAuthorizedKeysPath returns the path to the authorized keys file
 // for the specified username
 func formatUsernameForAuthorizedKeysPath(username string) string {
@@ -138,11 +282,19 @@ func SSHKeySchemaHash(v interface{}) int {
 	var buf bytes.Buffer
 
 	if m, ok := v.(map[string]interface{}); ok {
-		normalisedKey, err := utils.NormalizeSSHKey(m["public_key"].(string))
-		if err != nil {
-			log.Printf("[DEBUG] error normalising ssh key %q: %+v", m["public_key"].(string), err)
+		// when the key is referenced the Public Key is computed, so the reference is hashed instead
+		if id, ok := m["ssh_public_key_id"].(string); ok && id != "" {
+			buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(id)))
+		} else {
+			publicKey := m["public_key"].(string)
+			normalisedKey, err := utils.NormalizeSSHKey(publicKey)
+			if err != nil {
+				// e.g. neither `public_key` or `ssh_public_key_id` has been specified, which is caught during the plan
+				log.Printf("[DEBUG] error normalising ssh key %q: %+v", publicKey, err)
+				normalisedKey = &publicKey
+			}
+			buf.WriteString(fmt.Sprintf("%s-", *normalisedKey))
 		}
-		buf.WriteString(fmt.Sprintf("%s-", *normalisedKey))
 		buf.WriteString(fmt.Sprintf("%s", m["username"]))
 	}
 
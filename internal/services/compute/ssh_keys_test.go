@@ -61,3 +61,120 @@ func TestParseUsernameFromAuthorizedKeysPath(t *testing.T) {
 		}
 	}
 }
+
+func TestFlattenSSHKeysWithReferences(t *testing.T) {
+	keyId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Compute/sshPublicKeys/key1"
+
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		Existing []interface{}
+		Expected []string
+	}{
+		{
+			Name: "No Existing Keys",
+			Input: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser"},
+			},
+			Expected: []string{""},
+		},
+		{
+			Name: "Inline Key",
+			Input: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser"},
+			},
+			Existing: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser", "ssh_public_key_id": ""},
+			},
+			Expected: []string{""},
+		},
+		{
+			Name: "Unresolved Reference",
+			Input: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser"},
+			},
+			Existing: []interface{}{
+				map[string]interface{}{"public_key": "", "username": "adminuser", "ssh_public_key_id": keyId},
+			},
+			Expected: []string{keyId},
+		},
+		{
+			Name: "Resolved Reference",
+			Input: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser"},
+			},
+			Existing: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser", "ssh_public_key_id": keyId},
+			},
+			Expected: []string{keyId},
+		},
+		{
+			Name: "Reference and Inline Key for the same Username",
+			Input: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser"},
+				map[string]interface{}{"public_key": "ssh-rsa second", "username": "adminuser"},
+			},
+			Existing: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser", "ssh_public_key_id": ""},
+				map[string]interface{}{"public_key": "", "username": "adminuser", "ssh_public_key_id": keyId},
+			},
+			Expected: []string{"", keyId},
+		},
+		{
+			Name: "Reference for a different Username",
+			Input: []interface{}{
+				map[string]interface{}{"public_key": "ssh-rsa first", "username": "adminuser"},
+			},
+			Existing: []interface{}{
+				map[string]interface{}{"public_key": "", "username": "otheruser", "ssh_public_key_id": keyId},
+			},
+			Expected: []string{""},
+		},
+	}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		actual := flattenSSHKeysWithReferences(v.Input, v.Existing)
+		if len(actual) != len(v.Expected) {
+			t.Fatalf("Expected %d keys but got %d", len(v.Expected), len(actual))
+		}
+		for i, expected := range v.Expected {
+			if id := actual[i].(map[string]interface{})["ssh_public_key_id"].(string); id != expected {
+				t.Fatalf("Expected %q for key %d but got %q", expected, i, id)
+			}
+		}
+	}
+}
+
+func TestSSHKeySchemaHash(t *testing.T) {
+	keyId := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Compute/sshPublicKeys/key1"
+
+	testData := []struct {
+		Name   string
+		First  map[string]interface{}
+		Second map[string]interface{}
+		Equal  bool
+	}{
+		{
+			Name:   "Neither Public Key or Reference",
+			First:  map[string]interface{}{"public_key": "", "ssh_public_key_id": "", "username": "adminuser"},
+			Second: map[string]interface{}{"public_key": "", "ssh_public_key_id": "", "username": "otheruser"},
+			Equal:  false,
+		},
+		{
+			Name:   "Reference with and without a resolved Public Key",
+			First:  map[string]interface{}{"public_key": "", "ssh_public_key_id": keyId, "username": "adminuser"},
+			Second: map[string]interface{}{"public_key": "ssh-rsa first", "ssh_public_key_id": keyId, "username": "adminuser"},
+			Equal:  true,
+		},
+	}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.Name)
+
+		first := SSHKeySchemaHash(v.First)
+		second := SSHKeySchemaHash(v.Second)
+		if (first == second) != v.Equal {
+			t.Fatalf("Expected the hashes to be equal to be %t but got %d and %d", v.Equal, first, second)
+		}
+	}
+}
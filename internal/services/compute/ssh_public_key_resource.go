@@ -0,0 +1,195 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func sshPublicKey() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: sshPublicKeyCreate,
+		Read:   sshPublicKeyRead,
+		Update: sshPublicKeyUpdate,
+		Delete: sshPublicKeyDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.SSHPublicKeyID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupName(),
+
+			"location": commonschema.Location(),
+
+			// when omitted a Key Pair is generated, in which case the Private Key is exposed below
+			"public_key": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateFunc:     validate.SSHKey,
+				DiffSuppressFunc: SSHKeyDiffSuppress,
+			},
+
+			"private_key": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func sshPublicKeyCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SSHPublicKeysClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewSSHPublicKeyID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return tf.ImportAsExistsError("azurestack_ssh_public_key", id.ID())
+	}
+
+	publicKey := d.Get("public_key").(string)
+
+	parameters := compute.SSHPublicKeyResource{
+		Location:                       utils.String(location.Normalize(d.Get("location").(string))),
+		SSHPublicKeyResourceProperties: &compute.SSHPublicKeyResourceProperties{},
+		Tags:                           tags.Expand(d.Get("tags").(map[string]interface{})),
+	}
+	if publicKey != "" {
+		parameters.SSHPublicKeyResourceProperties.PublicKey = utils.String(publicKey)
+	}
+
+	if _, err := client.Create(ctx, id.ResourceGroup, id.Name, parameters); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	// the ID is set prior to generating the Key Pair so that the resource is tainted should that fail
+	d.SetId(id.ID())
+
+	if publicKey == "" {
+		log.Printf("[DEBUG] Generating a Key Pair for %s..", id)
+		keyPair, err := client.GenerateKeyPair(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return fmt.Errorf("generating a Key Pair for %s: %+v", id, err)
+		}
+		log.Printf("[DEBUG] Generated a Key Pair for %s.", id)
+
+		// the Private Key is only returned at generation time, so can't be retrieved during the Read
+		d.Set("private_key", keyPair.PrivateKey)
+	}
+
+	return sshPublicKeyRead(d, meta)
+}
+
+func sshPublicKeyUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SSHPublicKeysClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SSHPublicKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	update := compute.SSHPublicKeyUpdateResource{}
+
+	if d.HasChange("tags") {
+		update.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
+	}
+
+	if _, err := client.Update(ctx, id.ResourceGroup, id.Name, update); err != nil {
+		return fmt.Errorf("updating %s: %+v", *id, err)
+	}
+
+	return sshPublicKeyRead(d, meta)
+}
+
+func sshPublicKeyRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SSHPublicKeysClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SSHPublicKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[INFO] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+
+	if props := resp.SSHPublicKeyResourceProperties; props != nil {
+		d.Set("public_key", props.PublicKey)
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func sshPublicKeyDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.SSHPublicKeysClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.SSHPublicKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Delete(ctx, id.ResourceGroup, id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// ImageVersionConstraint validates that the value is either `latest` or a valid version constraint (such as `~> 2022.1`)
+func ImageVersionConstraint(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if strings.EqualFold(v, "latest") {
+		return nil, nil
+	}
+
+	if _, err := version.NewConstraint(v); err != nil {
+		return nil, []error{fmt.Errorf("%q must be `latest` or a valid version constraint (such as `~> 2022.1`), got %q: %+v", k, v, err)}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,51 @@
+package validate
+
+import "testing"
+
+func TestImageVersionConstraint(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			input:    "latest",
+			expected: true,
+		},
+		{
+			input:    "Latest",
+			expected: true,
+		},
+		{
+			// exact version
+			input:    "16.04.202007080",
+			expected: true,
+		},
+		{
+			input:    "~> 2022.1",
+			expected: true,
+		},
+		{
+			input:    ">= 2022.1.0, < 2023.0.0",
+			expected: true,
+		},
+		{
+			input:    "newest",
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := ImageVersionConstraint(v.input, "version")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -178,6 +178,59 @@ func virtualMachineOSDiskSchema() *pluginsdk.Schema {
 	}
 }
 
+func virtualMachineOSDiskSchemaForDataSource() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"caching": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"storage_account_type": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"diff_disk_settings": {
+					Type:     pluginsdk.TypeList,
+					Computed: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"option": {
+								Type:     pluginsdk.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+
+				"disk_encryption_set_id": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"disk_size_gb": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"write_accelerator_enabled": {
+					Type:     pluginsdk.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
 func expandVirtualMachineOSDisk(input []interface{}, osType compute.OperatingSystemTypes) *compute.OSDisk {
 	raw := input[0].(map[string]interface{})
 	disk := compute.OSDisk{
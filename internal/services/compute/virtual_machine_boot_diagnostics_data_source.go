@@ -0,0 +1,185 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	intStor "github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/client"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/blob/blobs"
+)
+
+func virtualMachineBootDiagnosticsDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineBootDiagnosticsDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"virtual_machine_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.VirtualMachineID,
+				ExactlyOneOf: []string{"virtual_machine_id", "virtual_machine_scale_set_id"},
+			},
+
+			"virtual_machine_scale_set_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.VirtualMachineScaleSetID,
+				ExactlyOneOf: []string{"virtual_machine_id", "virtual_machine_scale_set_id"},
+				RequiredWith: []string{"instance_id"},
+			},
+
+			"instance_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				RequiredWith: []string{"virtual_machine_scale_set_id"},
+			},
+
+			"sas_uri_expiration_in_minutes": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      120,
+				ValidateFunc: validation.IntBetween(1, 1440),
+			},
+
+			"include_serial_console_log_content": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"console_screenshot_blob_uri": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"serial_console_log_blob_uri": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"serial_console_log_content": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func virtualMachineBootDiagnosticsDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	expiration := utils.Int32(int32(d.Get("sas_uri_expiration_in_minutes").(int)))
+
+	var id string
+	var result compute.RetrieveBootDiagnosticsDataResult
+	if v := d.Get("virtual_machine_id").(string); v != "" {
+		vmId, err := parse.VirtualMachineID(v)
+		if err != nil {
+			return err
+		}
+
+		client := meta.(*clients.Client).Compute.VMClient
+		result, err = client.RetrieveBootDiagnosticsData(ctx, vmId.ResourceGroup, vmId.Name, expiration)
+		if err != nil {
+			if utils.ResponseWasNotFound(result.Response) {
+				return fmt.Errorf("%s was not found", *vmId)
+			}
+			return fmt.Errorf("retrieving Boot Diagnostics Data for %s: %+v", *vmId, err)
+		}
+
+		id = vmId.ID()
+	} else {
+		scaleSetId, err := parse.VirtualMachineScaleSetID(d.Get("virtual_machine_scale_set_id").(string))
+		if err != nil {
+			return err
+		}
+		instanceId := d.Get("instance_id").(string)
+
+		client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+		result, err = client.RetrieveBootDiagnosticsData(ctx, scaleSetId.ResourceGroup, scaleSetId.Name, instanceId, expiration)
+		if err != nil {
+			if utils.ResponseWasNotFound(result.Response) {
+				return fmt.Errorf("instance %q of %s was not found", instanceId, *scaleSetId)
+			}
+			return fmt.Errorf("retrieving Boot Diagnostics Data for instance %q of %s: %+v", instanceId, *scaleSetId, err)
+		}
+
+		id = parse.NewVirtualMachineScaleSetVirtualMachineID(scaleSetId.SubscriptionId, scaleSetId.ResourceGroup, scaleSetId.Name, instanceId).ID()
+	}
+
+	consoleScreenshotBlobUri := ""
+	if result.ConsoleScreenshotBlobURI != nil {
+		consoleScreenshotBlobUri = *result.ConsoleScreenshotBlobURI
+	}
+	serialConsoleLogBlobUri := ""
+	if result.SerialConsoleLogBlobURI != nil {
+		serialConsoleLogBlobUri = *result.SerialConsoleLogBlobURI
+	}
+
+	serialConsoleLogContent := ""
+	if d.Get("include_serial_console_log_content").(bool) {
+		if serialConsoleLogBlobUri == "" {
+			return fmt.Errorf("retrieving the Serial Console Log for %q: the Serial Console Log Blob URI was empty - is Boot Diagnostics enabled?", id)
+		}
+
+		content, err := virtualMachineBootDiagnosticsBlobContent(ctx, meta.(*clients.Client).Storage, serialConsoleLogBlobUri)
+		if err != nil {
+			return fmt.Errorf("retrieving the Serial Console Log for %q: %+v", id, err)
+		}
+		serialConsoleLogContent = content
+	}
+
+	d.SetId(id)
+
+	d.Set("console_screenshot_blob_uri", consoleScreenshotBlobUri)
+	d.Set("serial_console_log_blob_uri", serialConsoleLogBlobUri)
+	d.Set("serial_console_log_content", serialConsoleLogContent)
+
+	return nil
+}
+
+// virtualMachineBootDiagnosticsBlobContent downloads the contents of the Boot Diagnostics blob using the Storage
+// Account's access key, since the Storage Account used for Boot Diagnostics lives within the Subscription
+func virtualMachineBootDiagnosticsBlobContent(ctx context.Context, storageClient *intStor.Client, uri string) (string, error) {
+	id, err := blobs.ParseResourceID(uri)
+	if err != nil {
+		return "", fmt.Errorf("parsing %q: %s", uri, err)
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return "", fmt.Errorf("retrieving Account %q for Blob %q (Container %q): %s", id.AccountName, id.BlobName, id.ContainerName, err)
+	}
+	if account == nil {
+		return "", fmt.Errorf("unable to locate Storage Account %q (Blob %q / Container %q)", id.AccountName, id.BlobName, id.ContainerName)
+	}
+
+	blobsClient, err := storageClient.BlobsClient(ctx, *account)
+	if err != nil {
+		return "", fmt.Errorf("building Blobs Client: %s", err)
+	}
+
+	resp, err := blobsClient.Get(ctx, id.AccountName, id.ContainerName, id.BlobName, blobs.GetInput{})
+	if err != nil {
+		return "", fmt.Errorf("retrieving Blob %q (Container %q / Account %q / Resource Group %q): %s", id.BlobName, id.ContainerName, id.AccountName, account.ResourceGroup, err)
+	}
+
+	return string(resp.Contents), nil
+}
@@ -0,0 +1,110 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualMachineBootDiagnosticsDataSource struct{}
+
+func TestAccVirtualMachineBootDiagnosticsDataSource_virtualMachine(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_boot_diagnostics", "test")
+	r := VirtualMachineBootDiagnosticsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.virtualMachine(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("serial_console_log_blob_uri").Exists(),
+				check.That(data.ResourceName).Key("console_screenshot_blob_uri").Exists(),
+				check.That(data.ResourceName).Key("serial_console_log_content").HasValue(""),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineBootDiagnosticsDataSource_serialConsoleLogContent(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_boot_diagnostics", "test")
+	r := VirtualMachineBootDiagnosticsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.serialConsoleLogContent(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("serial_console_log_blob_uri").Exists(),
+				check.That(data.ResourceName).Key("serial_console_log_content").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualMachineBootDiagnosticsDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                = "acctestVM-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  size                = "Standard_F2"
+  admin_username      = "adminuser"
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  admin_ssh_key {
+    username   = "adminuser"
+    public_key = local.first_public_key
+  }
+
+  boot_diagnostics {
+    storage_account_uri = azurestack_storage_account.test.primary_blob_endpoint
+  }
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+`, LinuxVirtualMachineResource{}.template(data), data.RandomString, data.RandomInteger)
+}
+
+func (r VirtualMachineBootDiagnosticsDataSource) virtualMachine(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine_boot_diagnostics" "test" {
+  virtual_machine_id = azurestack_linux_virtual_machine.test.id
+}
+`, r.template(data))
+}
+
+func (r VirtualMachineBootDiagnosticsDataSource) serialConsoleLogContent(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine_boot_diagnostics" "test" {
+  virtual_machine_id                 = azurestack_linux_virtual_machine.test.id
+  sas_uri_expiration_in_minutes      = 30
+  include_serial_console_log_content = true
+}
+`, r.template(data))
+}
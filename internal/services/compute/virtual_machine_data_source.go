@@ -0,0 +1,198 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualMachineDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: virtualMachineDataSourceSchema(),
+	}
+}
+
+// virtualMachineDataSourceSchema returns the schema shared by the Virtual Machine Data Sources
+func virtualMachineDataSourceSchema() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+		"location": commonschema.LocationComputed(),
+
+		"availability_set_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"network_interface_ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"os_disk": virtualMachineOSDiskSchemaForDataSource(),
+
+		"os_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"power_state": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"private_ip_address": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"private_ip_addresses": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"public_ip_address": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"public_ip_addresses": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"size": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"virtual_machine_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"tags": tags.SchemaDataSource(),
+	}
+}
+
+func virtualMachineDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewVirtualMachineID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, compute.InstanceView)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	if err := setVirtualMachineDataSourceProperties(ctx, d, meta, resp); err != nil {
+		return fmt.Errorf("setting properties for %s: %+v", id, err)
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+// setVirtualMachineDataSourceProperties sets the fields defined in virtualMachineDataSourceSchema
+// from a Virtual Machine retrieved with its Instance View
+func setVirtualMachineDataSourceProperties(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}, vm compute.VirtualMachine) error {
+	disksClient := meta.(*clients.Client).Compute.DisksClient
+	networkInterfacesClient := meta.(*clients.Client).Network.InterfacesClient
+	publicIPAddressesClient := meta.(*clients.Client).Network.PublicIPsClient
+
+	d.Set("location", location.NormalizeNilable(vm.Location))
+
+	props := vm.VirtualMachineProperties
+	if props == nil {
+		return fmt.Errorf("`properties` was nil")
+	}
+
+	availabilitySetId := ""
+	if props.AvailabilitySet != nil && props.AvailabilitySet.ID != nil {
+		availabilitySetId = *props.AvailabilitySet.ID
+	}
+	d.Set("availability_set_id", availabilitySetId)
+
+	if profile := props.HardwareProfile; profile != nil {
+		d.Set("size", string(profile.VMSize))
+	}
+
+	networkInterfaceIds := make([]interface{}, 0)
+	if profile := props.NetworkProfile; profile != nil {
+		networkInterfaceIds = flattenVirtualMachineNetworkInterfaceIDs(profile.NetworkInterfaces)
+	}
+	if err := d.Set("network_interface_ids", networkInterfaceIds); err != nil {
+		return fmt.Errorf("setting `network_interface_ids`: %+v", err)
+	}
+
+	osType := ""
+	if profile := props.StorageProfile; profile != nil {
+		if profile.OsDisk != nil {
+			osType = string(profile.OsDisk.OsType)
+		}
+
+		// the storage_account_type isn't returned so we need to look it up
+		flattenedOSDisk, err := flattenVirtualMachineOSDisk(ctx, disksClient, profile.OsDisk)
+		if err != nil {
+			return fmt.Errorf("flattening `os_disk`: %+v", err)
+		}
+		if err := d.Set("os_disk", flattenedOSDisk); err != nil {
+			return fmt.Errorf("setting `os_disk`: %+v", err)
+		}
+	}
+	d.Set("os_type", osType)
+
+	var instanceViewStatuses *[]compute.InstanceViewStatus
+	if props.InstanceView != nil {
+		instanceViewStatuses = props.InstanceView.Statuses
+	}
+	d.Set("power_state", virtualMachinePowerStateFromStatuses(instanceViewStatuses))
+	d.Set("virtual_machine_id", props.VMID)
+
+	connectionInfo := retrieveConnectionInformation(ctx, networkInterfacesClient, publicIPAddressesClient, props)
+	d.Set("private_ip_address", connectionInfo.primaryPrivateAddress)
+	d.Set("private_ip_addresses", connectionInfo.privateAddresses)
+	d.Set("public_ip_address", connectionInfo.primaryPublicAddress)
+	d.Set("public_ip_addresses", connectionInfo.publicAddresses)
+
+	return nil
+}
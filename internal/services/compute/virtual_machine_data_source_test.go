@@ -0,0 +1,42 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualMachineDataSource struct{}
+
+func TestAccVirtualMachineDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine", "test")
+	r := VirtualMachineDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("location").Exists(),
+				check.That(data.ResourceName).Key("size").Exists(),
+				check.That(data.ResourceName).Key("os_type").HasValue("Linux"),
+				check.That(data.ResourceName).Key("power_state").HasValue("running"),
+				check.That(data.ResourceName).Key("network_interface_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("private_ip_address").Exists(),
+				check.That(data.ResourceName).Key("os_disk.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (VirtualMachineDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine" "test" {
+  name                = azurestack_virtual_machine.test.name
+  resource_group_name = azurestack_virtual_machine.test.resource_group_name
+}
+`, VirtualMachineResource{}.basicLinuxMachine(data))
+}
@@ -0,0 +1,268 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+// cachedVirtualMachineExtensionImageVersions holds the versions of each Extension Image available on the stamp,
+// keyed by the normalized location, publisher and type. Only successful lookups are cached.
+var (
+	cachedVirtualMachineExtensionImageVersions     = map[string][]string{}
+	cachedVirtualMachineExtensionImageVersionsLock = sync.Mutex{}
+)
+
+// checkVirtualMachineExtensionImageType confirms that the Extension Image type is available for the publisher in
+// the location, since stamps only contain the Extensions syndicated by the operator. A description of the problem
+// is returned when it's unavailable, whereas an error is only returned when this couldn't be determined.
+func checkVirtualMachineExtensionImageType(ctx context.Context, client *compute.VirtualMachineExtensionImagesClient, loc, publisher, imageType string) (string, error) {
+	types, err := client.ListTypes(ctx, loc, publisher)
+	if err != nil {
+		if utils.ResponseWasNotFound(types.Response) {
+			return fmt.Sprintf("no Extension Images were found for the publisher %q in location %q", publisher, loc), nil
+		}
+		return "", fmt.Errorf("listing Extension Image types for the publisher %q in location %q: %+v", publisher, loc, err)
+	}
+
+	availableTypes := make([]string, 0)
+	if types.Value != nil {
+		for _, v := range *types.Value {
+			if v.Name == nil {
+				continue
+			}
+			if strings.EqualFold(*v.Name, imageType) {
+				return "", nil
+			}
+			availableTypes = append(availableTypes, *v.Name)
+		}
+	}
+
+	sort.Strings(availableTypes)
+	return fmt.Sprintf("the Extension Image type %q was not found for the publisher %q in location %q - available types are: %s", imageType, publisher, loc, strings.Join(availableTypes, ", ")), nil
+}
+
+// listVirtualMachineExtensionImageVersions returns the versions of the specified Extension Image which are
+// available in the location, sorted from oldest to newest
+func listVirtualMachineExtensionImageVersions(ctx context.Context, client *compute.VirtualMachineExtensionImagesClient, loc, publisher, imageType string) ([]string, error) {
+	resp, err := client.ListVersions(ctx, loc, publisher, imageType, "", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing versions of the Extension Image %q (publisher %q) in location %q: %+v", imageType, publisher, loc, err)
+	}
+
+	versions := make([]string, 0)
+	if resp.Value != nil {
+		for _, v := range *resp.Value {
+			if v.Name != nil {
+				versions = append(versions, *v.Name)
+			}
+		}
+	}
+
+	return sortVirtualMachineExtensionImageVersions(versions), nil
+}
+
+// sortVirtualMachineExtensionImageVersions sorts the versions from oldest to newest
+func sortVirtualMachineExtensionImageVersions(input []string) []string {
+	output := make([]string, len(input))
+	copy(output, input)
+
+	sort.SliceStable(output, func(i, j int) bool {
+		return imageVersionLessThan(output[i], output[j])
+	})
+
+	return output
+}
+
+// resolveVirtualMachineExtensionImageVersion returns the newest version from `versions` (which must be sorted
+// from oldest to newest) matching the specified `input` - which is either `latest`, an exact version or a
+// version constraint such as `~> 2.0`
+func resolveVirtualMachineExtensionImageVersion(versions []string, input string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no versions are available")
+	}
+
+	if strings.EqualFold(input, "latest") {
+		return versions[len(versions)-1], nil
+	}
+
+	for _, v := range versions {
+		if v == input {
+			return v, nil
+		}
+	}
+
+	constraint, err := version.NewConstraint(input)
+	if err != nil {
+		return "", fmt.Errorf("the version %q was not found and isn't a valid version constraint: %+v", input, err)
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		v, err := version.NewVersion(versions[i])
+		if err != nil {
+			continue
+		}
+		if constraint.Check(v) {
+			return versions[i], nil
+		}
+	}
+
+	return "", fmt.Errorf("no versions matching %q were found - available versions are: %s", input, strings.Join(versions, ", "))
+}
+
+// virtualMachineExtensionImageVersionExists returns whether the `type_handler_version` matches an available
+// version, where a Type Handler Version (e.g. `2.0`) matches any version sharing the same leading segments
+func virtualMachineExtensionImageVersionExists(versions []string, typeHandlerVersion string) bool {
+	requested, err := version.NewVersion(typeHandlerVersion)
+	if err != nil {
+		// not something we can reason about, so leave it to the API
+		return true
+	}
+	// Segments pads the version to at least 3 segments, however only those specified should be compared
+	requestedSegments := requested.Segments()
+	if specified := len(strings.Split(strings.TrimPrefix(typeHandlerVersion, "v"), ".")); specified < len(requestedSegments) {
+		requestedSegments = requestedSegments[:specified]
+	}
+
+	for _, v := range versions {
+		if v == typeHandlerVersion {
+			return true
+		}
+
+		available, err := version.NewVersion(v)
+		if err != nil {
+			continue
+		}
+
+		availableSegments := available.Segments()
+		matches := true
+		for i, segment := range requestedSegments {
+			if i >= len(availableSegments) || availableSegments[i] != segment {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// virtualMachineExtensionImageCustomizeDiff returns a CustomizeDiffFunc which validates that the `type_handler_version`
+// is available on the stamp for the Extension's `publisher` and `type`. The location is determined from the parent
+// resource returned by `locationFunc`, as such this is only validated when the parent resource already exists.
+//
+// NOTE: this is best-effort - when Enhanced Validation is disabled, or the parent resource or available versions
+// can't be retrieved, no validation is performed and the API will surface any error as before.
+func virtualMachineExtensionImageCustomizeDiff(locationFunc func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		if !features.EnhancedValidationEnabled() {
+			return nil
+		}
+
+		if !d.HasChange("publisher") && !d.HasChange("type") && !d.HasChange("type_handler_version") {
+			return nil
+		}
+		for _, field := range []string{"publisher", "type", "type_handler_version"} {
+			if !d.NewValueKnown(field) || d.Get(field).(string) == "" {
+				return nil
+			}
+		}
+
+		loc := locationFunc(ctx, d, meta)
+		if loc == "" {
+			return nil
+		}
+
+		publisher := d.Get("publisher").(string)
+		imageType := d.Get("type").(string)
+		typeHandlerVersion := d.Get("type_handler_version").(string)
+
+		key := strings.ToLower(fmt.Sprintf("%s/%s/%s", loc, publisher, imageType))
+		cachedVirtualMachineExtensionImageVersionsLock.Lock()
+		versions, ok := cachedVirtualMachineExtensionImageVersions[key]
+		cachedVirtualMachineExtensionImageVersionsLock.Unlock()
+
+		if !ok {
+			client := meta.(*clients.Client).Compute.VMExtensionImageClient
+			problem, err := checkVirtualMachineExtensionImageType(ctx, client, loc, publisher, imageType)
+			if err != nil {
+				log.Printf("[DEBUG] %+v. Enhanced validation will be unavailable", err)
+				return nil
+			}
+			if problem != "" {
+				return fmt.Errorf("%s", problem)
+			}
+
+			versions, err = listVirtualMachineExtensionImageVersions(ctx, client, loc, publisher, imageType)
+			if err != nil {
+				log.Printf("[DEBUG] %+v. Enhanced validation will be unavailable", err)
+				return nil
+			}
+
+			cachedVirtualMachineExtensionImageVersionsLock.Lock()
+			cachedVirtualMachineExtensionImageVersions[key] = versions
+			cachedVirtualMachineExtensionImageVersionsLock.Unlock()
+		}
+
+		if len(versions) == 0 || virtualMachineExtensionImageVersionExists(versions, typeHandlerVersion) {
+			return nil
+		}
+
+		return fmt.Errorf("the `type_handler_version` %q is not available for the Extension Image %q (publisher %q) in location %q - available versions are: %s", typeHandlerVersion, imageType, publisher, loc, strings.Join(versions, ", "))
+	}
+}
+
+func virtualMachineExtensionLocation(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) string {
+	if !d.NewValueKnown("virtual_machine_id") {
+		return ""
+	}
+
+	id, err := parse.VirtualMachineID(d.Get("virtual_machine_id").(string))
+	if err != nil {
+		return ""
+	}
+
+	client := meta.(*clients.Client).Compute.VMClient
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		log.Printf("[DEBUG] retrieving %s to validate the Extension Image: %+v", *id, err)
+		return ""
+	}
+
+	return location.NormalizeNilable(resp.Location)
+}
+
+func virtualMachineScaleSetExtensionLocation(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) string {
+	if !d.NewValueKnown("virtual_machine_scale_set_id") {
+		return ""
+	}
+
+	id, err := parse.VirtualMachineScaleSetID(d.Get("virtual_machine_scale_set_id").(string))
+	if err != nil {
+		return ""
+	}
+
+	client := meta.(*clients.Client).Compute.VMScaleSetClient
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		log.Printf("[DEBUG] retrieving %s to validate the Extension Image: %+v", *id, err)
+		return ""
+	}
+
+	return location.NormalizeNilable(resp.Location)
+}
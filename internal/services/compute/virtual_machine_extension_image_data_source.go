@@ -0,0 +1,168 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func virtualMachineExtensionImageDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineExtensionImageDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"location": commonschema.Location(),
+
+			"publisher": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"type": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			// either `latest`, an exact version or a version constraint such as `~> 2.0`
+			"version": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Default:      "latest",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resolved_version": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"type_handler_version": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"versions": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"operating_system": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"compute_role": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"supports_multiple_extensions": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"virtual_machine_scale_set_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func virtualMachineExtensionImageDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMExtensionImageClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	loc := location.Normalize(d.Get("location").(string))
+	publisher := d.Get("publisher").(string)
+	imageType := d.Get("type").(string)
+
+	problem, err := checkVirtualMachineExtensionImageType(ctx, client, loc, publisher, imageType)
+	if err != nil {
+		return err
+	}
+	if problem != "" {
+		return fmt.Errorf("%s", problem)
+	}
+
+	versions, err := listVirtualMachineExtensionImageVersions(ctx, client, loc, publisher, imageType)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := resolveVirtualMachineExtensionImageVersion(versions, d.Get("version").(string))
+	if err != nil {
+		return fmt.Errorf("resolving the version of the Extension Image %q (publisher %q) in location %q: %+v", imageType, publisher, loc, err)
+	}
+
+	image, err := client.Get(ctx, loc, publisher, imageType, resolved)
+	if err != nil {
+		return fmt.Errorf("retrieving version %q of the Extension Image %q (publisher %q) in location %q: %+v", resolved, imageType, publisher, loc, err)
+	}
+	if image.ID == nil {
+		return fmt.Errorf("retrieving version %q of the Extension Image %q (publisher %q) in location %q: `id` was nil", resolved, imageType, publisher, loc)
+	}
+
+	d.SetId(*image.ID)
+
+	d.Set("location", loc)
+	d.Set("resolved_version", resolved)
+	d.Set("type_handler_version", virtualMachineExtensionTypeHandlerVersion(resolved))
+	if err := d.Set("versions", versions); err != nil {
+		return fmt.Errorf("setting `versions`: %+v", err)
+	}
+
+	operatingSystem := ""
+	computeRole := ""
+	supportsMultipleExtensions := false
+	scaleSetEnabled := false
+	if props := image.VirtualMachineExtensionImageProperties; props != nil {
+		if props.OperatingSystem != nil {
+			operatingSystem = *props.OperatingSystem
+		}
+		if props.ComputeRole != nil {
+			computeRole = *props.ComputeRole
+		}
+		if props.SupportsMultipleExtensions != nil {
+			supportsMultipleExtensions = *props.SupportsMultipleExtensions
+		}
+		if props.VMScaleSetEnabled != nil {
+			scaleSetEnabled = *props.VMScaleSetEnabled
+		}
+	}
+	d.Set("operating_system", operatingSystem)
+	d.Set("compute_role", computeRole)
+	d.Set("supports_multiple_extensions", supportsMultipleExtensions)
+	d.Set("virtual_machine_scale_set_enabled", scaleSetEnabled)
+
+	return nil
+}
+
+// virtualMachineExtensionTypeHandlerVersion returns the `major.minor` form of the version, which is the format
+// expected for the `type_handler_version` of an Extension
+func virtualMachineExtensionTypeHandlerVersion(input string) string {
+	v, err := version.NewVersion(input)
+	if err != nil {
+		return input
+	}
+
+	segments := v.Segments()
+	return fmt.Sprintf("%d.%d", segments[0], segments[1])
+}
@@ -0,0 +1,58 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualMachineExtensionImageDataSource struct{}
+
+func TestAccVirtualMachineExtensionImageDataSource_latest(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_extension_image", "test")
+	r := VirtualMachineExtensionImageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "latest"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resolved_version").Exists(),
+				check.That(data.ResourceName).Key("type_handler_version").Exists(),
+				check.That(data.ResourceName).Key("versions.#").Exists(),
+				check.That(data.ResourceName).Key("operating_system").HasValue("Linux"),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineExtensionImageDataSource_constraint(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_extension_image", "test")
+	r := VirtualMachineExtensionImageDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "~> 2.0"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resolved_version").Exists(),
+				check.That(data.ResourceName).Key("type_handler_version").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualMachineExtensionImageDataSource) basic(data acceptance.TestData, version string) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_virtual_machine_extension_image" "test" {
+  location  = "%s"
+  publisher = "Microsoft.Azure.Extensions"
+  type      = "CustomScript"
+  version   = "%s"
+}
+`, data.Locations.Primary, version)
+}
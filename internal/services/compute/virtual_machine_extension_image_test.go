@@ -0,0 +1,142 @@
+package compute
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortVirtualMachineExtensionImageVersions(t *testing.T) {
+	input := []string{"2.1.3", "1.10.1", "2.0.7", "1.9.5", "invalid"}
+	expected := []string{"invalid", "1.9.5", "1.10.1", "2.0.7", "2.1.3"}
+
+	actual := sortVirtualMachineExtensionImageVersions(input)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestResolveVirtualMachineExtensionImageVersion(t *testing.T) {
+	versions := []string{"1.9.5", "1.10.1", "2.0.7", "2.1.3"}
+
+	testCases := []struct {
+		Name     string
+		Input    string
+		Expected string
+		Error    bool
+	}{
+		{
+			Name:     "Latest",
+			Input:    "latest",
+			Expected: "2.1.3",
+		},
+		{
+			Name:     "Latest Different Casing",
+			Input:    "Latest",
+			Expected: "2.1.3",
+		},
+		{
+			Name:     "Exact",
+			Input:    "1.10.1",
+			Expected: "1.10.1",
+		},
+		{
+			Name:     "Pessimistic Constraint",
+			Input:    "~> 1.9",
+			Expected: "1.10.1",
+		},
+		{
+			Name:     "Range Constraint",
+			Input:    ">= 2.0, < 2.1",
+			Expected: "2.0.7",
+		},
+		{
+			Name:  "No Matching Versions",
+			Input: "~> 3.0",
+			Error: true,
+		},
+		{
+			Name:  "Invalid Constraint",
+			Input: "not-a-version",
+			Error: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+		actual, err := resolveVirtualMachineExtensionImageVersion(versions, testCase.Input)
+		if err != nil {
+			if testCase.Error {
+				continue
+			}
+			t.Fatalf("Expected no error but got: %+v", err)
+		}
+		if testCase.Error {
+			t.Fatalf("Expected an error but got %q", actual)
+		}
+		if actual != testCase.Expected {
+			t.Fatalf("Expected %q but got %q", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestVirtualMachineExtensionImageVersionExists(t *testing.T) {
+	versions := []string{"1.9.5", "1.10.1", "2.0.7"}
+
+	testCases := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "2.0",
+			Expected: true,
+		},
+		{
+			Input:    "1.10",
+			Expected: true,
+		},
+		{
+			Input:    "1.9.5",
+			Expected: true,
+		},
+		{
+			Input:    "1.1",
+			Expected: false,
+		},
+		{
+			Input:    "2.1",
+			Expected: false,
+		},
+		{
+			Input:    "3",
+			Expected: false,
+		},
+		{
+			// can't be parsed, so is left to the API to validate
+			Input:    "not-a-version",
+			Expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Input)
+		if actual := virtualMachineExtensionImageVersionExists(versions, testCase.Input); actual != testCase.Expected {
+			t.Fatalf("Expected %t but got %t", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestVirtualMachineExtensionTypeHandlerVersion(t *testing.T) {
+	testCases := map[string]string{
+		"2.1.3":   "2.1",
+		"1.10":    "1.10",
+		"3":       "3.0",
+		"invalid": "invalid",
+	}
+
+	for input, expected := range testCases {
+		t.Logf("Running %q..", input)
+		if actual := virtualMachineExtensionTypeHandlerVersion(input); actual != expected {
+			t.Fatalf("Expected %q but got %q", expected, actual)
+		}
+	}
+}
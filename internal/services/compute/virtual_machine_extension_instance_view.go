@@ -0,0 +1,141 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+func virtualMachineExtensionInstanceViewSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"statuses": virtualMachineExtensionInstanceViewStatusSchema(),
+
+				"substatuses": virtualMachineExtensionInstanceViewStatusSchema(),
+			},
+		},
+	}
+}
+
+func virtualMachineExtensionInstanceViewStatusSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"code": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"level": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"display_status": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"message": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenVirtualMachineExtensionInstanceView(input *compute.VirtualMachineExtensionInstanceView) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"statuses":    flattenVirtualMachineExtensionInstanceViewStatuses(input.Statuses),
+			"substatuses": flattenVirtualMachineExtensionInstanceViewStatuses(input.Substatuses),
+		},
+	}
+}
+
+func flattenVirtualMachineExtensionInstanceViewStatuses(input *[]compute.InstanceViewStatus) []interface{} {
+	output := make([]interface{}, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		code := ""
+		if v.Code != nil {
+			code = *v.Code
+		}
+		displayStatus := ""
+		if v.DisplayStatus != nil {
+			displayStatus = *v.DisplayStatus
+		}
+		message := ""
+		if v.Message != nil {
+			message = *v.Message
+		}
+
+		output = append(output, map[string]interface{}{
+			"code":           code,
+			"level":          string(v.Level),
+			"display_status": displayStatus,
+			"message":        message,
+		})
+	}
+
+	return output
+}
+
+// virtualMachineExtensionInstanceViewError returns an error describing the statuses of the Extension's Instance View
+// which have the level `Error` - including the messages of any substatuses, since Extensions such as CustomScript
+// report their output (e.g. StdErr) here - or nil when there are none
+func virtualMachineExtensionInstanceViewError(input *compute.VirtualMachineExtensionInstanceView) error {
+	if input == nil || input.Statuses == nil {
+		return nil
+	}
+
+	messages := make([]string, 0)
+	for _, status := range *input.Statuses {
+		if status.Level != compute.Error {
+			continue
+		}
+
+		message := ""
+		if status.Code != nil {
+			message = *status.Code
+		}
+		if status.Message != nil && *status.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, *status.Message)
+		}
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	if input.Substatuses != nil {
+		for _, substatus := range *input.Substatuses {
+			if substatus.Message == nil || *substatus.Message == "" {
+				continue
+			}
+
+			code := ""
+			if substatus.Code != nil {
+				code = *substatus.Code
+			}
+			messages = append(messages, fmt.Sprintf("%s: %s", code, *substatus.Message))
+		}
+	}
+
+	return fmt.Errorf("the Extension reported an error status:\n\n%s", strings.Join(messages, "\n\n"))
+}
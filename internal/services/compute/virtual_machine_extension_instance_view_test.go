@@ -0,0 +1,145 @@
+package compute
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestVirtualMachineExtensionInstanceViewError(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    *compute.VirtualMachineExtensionInstanceView
+		Expected []string
+	}{
+		{
+			Name:  "None",
+			Input: nil,
+		},
+		{
+			Name:  "No Statuses",
+			Input: &compute.VirtualMachineExtensionInstanceView{},
+		},
+		{
+			Name: "Succeeded",
+			Input: &compute.VirtualMachineExtensionInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{
+					{
+						Code:    utils.String("ProvisioningState/succeeded"),
+						Level:   compute.Info,
+						Message: utils.String("Enable succeeded"),
+					},
+				},
+				Substatuses: &[]compute.InstanceViewStatus{
+					{
+						Code:    utils.String("ComponentStatus/StdErr/succeeded"),
+						Level:   compute.Info,
+						Message: utils.String("some warning"),
+					},
+				},
+			},
+		},
+		{
+			Name: "Failed",
+			Input: &compute.VirtualMachineExtensionInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{
+					{
+						Code:    utils.String("ProvisioningState/failed/1"),
+						Level:   compute.Error,
+						Message: utils.String("Enable failed: failed to execute command"),
+					},
+				},
+				Substatuses: &[]compute.InstanceViewStatus{
+					{
+						Code:    utils.String("ComponentStatus/StdOut/succeeded"),
+						Level:   compute.Info,
+						Message: utils.String(""),
+					},
+					{
+						Code:    utils.String("ComponentStatus/StdErr/succeeded"),
+						Level:   compute.Info,
+						Message: utils.String("configuration failed"),
+					},
+				},
+			},
+			Expected: []string{
+				"ProvisioningState/failed/1: Enable failed: failed to execute command",
+				"ComponentStatus/StdErr/succeeded: configuration failed",
+			},
+		},
+		{
+			Name: "Failed Without Message",
+			Input: &compute.VirtualMachineExtensionInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{
+					{
+						Code:  utils.String("ProvisioningState/failed"),
+						Level: compute.Error,
+					},
+				},
+			},
+			Expected: []string{
+				"ProvisioningState/failed",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+
+		err := virtualMachineExtensionInstanceViewError(testCase.Input)
+		if len(testCase.Expected) == 0 {
+			if err != nil {
+				t.Fatalf("Expected no error but got %+v", err)
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Fatalf("Expected an error but didn't get one")
+		}
+		for _, v := range testCase.Expected {
+			if !strings.Contains(err.Error(), v) {
+				t.Fatalf("Expected the error %q to contain %q", err.Error(), v)
+			}
+		}
+		if strings.Contains(err.Error(), "StdOut") {
+			t.Fatalf("Expected the error %q not to contain empty substatuses", err.Error())
+		}
+	}
+}
+
+func TestFlattenVirtualMachineExtensionInstanceView(t *testing.T) {
+	if actual := flattenVirtualMachineExtensionInstanceView(nil); len(actual) != 0 {
+		t.Fatalf("Expected no items but got %d", len(actual))
+	}
+
+	actual := flattenVirtualMachineExtensionInstanceView(&compute.VirtualMachineExtensionInstanceView{
+		Statuses: &[]compute.InstanceViewStatus{
+			{
+				Code:          utils.String("ProvisioningState/succeeded"),
+				Level:         compute.Info,
+				DisplayStatus: utils.String("Provisioning succeeded"),
+				Message:       utils.String("Enable succeeded"),
+			},
+		},
+	})
+	if len(actual) != 1 {
+		t.Fatalf("Expected 1 item but got %d", len(actual))
+	}
+
+	instanceView := actual[0].(map[string]interface{})
+	if substatuses := instanceView["substatuses"].([]interface{}); len(substatuses) != 0 {
+		t.Fatalf("Expected no substatuses but got %d", len(substatuses))
+	}
+
+	statuses := instanceView["statuses"].([]interface{})
+	if len(statuses) != 1 {
+		t.Fatalf("Expected 1 status but got %d", len(statuses))
+	}
+	status := statuses[0].(map[string]interface{})
+	if status["code"] != "ProvisioningState/succeeded" || status["level"] != "Info" || status["display_status"] != "Provisioning succeeded" || status["message"] != "Enable succeeded" {
+		t.Fatalf("Unexpected status %+v", status)
+	}
+}
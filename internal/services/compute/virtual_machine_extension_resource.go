@@ -1,6 +1,7 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -35,6 +36,8 @@ func virtualMachineExtension() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineExtensionImageCustomizeDiff(virtualMachineExtensionLocation)),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:     pluginsdk.TypeString,
@@ -86,6 +89,14 @@ func virtualMachineExtension() *pluginsdk.Resource {
 				DiffSuppressFunc: pluginsdk.SuppressJsonDiff,
 			},
 
+			"fail_on_error_status": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"instance_view": virtualMachineExtensionInstanceViewSchema(),
+
 			"tags": tags.Schema(),
 		},
 	}
@@ -165,14 +176,40 @@ func virtualMachineExtensionsCreateUpdate(d *pluginsdk.ResourceData, meta interf
 	}
 
 	if err = future.WaitForCompletionRef(ctx, vmExtensionClient.Client); err != nil {
+		if d.Get("fail_on_error_status").(bool) {
+			// the error returned by the API is terse, so include the output of the Extension where it's available
+			if statusErr := virtualMachineExtensionStatusError(ctx, vmExtensionClient, id); statusErr != nil {
+				return fmt.Errorf("waiting for %s: %+v\n\n%+v", id, err, statusErr)
+			}
+		}
 		return err
 	}
 
 	d.SetId(id.ID()) // TODO before release confirm no state migration is required for this
 
+	if d.Get("fail_on_error_status").(bool) {
+		if err := virtualMachineExtensionStatusError(ctx, vmExtensionClient, id); err != nil {
+			return fmt.Errorf("provisioning %s: %+v", id, err)
+		}
+	}
+
 	return virtualMachineExtensionsRead(d, meta)
 }
 
+// virtualMachineExtensionStatusError retrieves the Instance View of the Extension and returns an error describing
+// any statuses with the level `Error`, or nil when there are none
+func virtualMachineExtensionStatusError(ctx context.Context, client *compute.VirtualMachineExtensionsClient, id parse.VirtualMachineExtensionId) error {
+	resp, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineName, id.ExtensionName, "instanceView")
+	if err != nil {
+		return fmt.Errorf("retrieving Instance View for %s: %+v", id, err)
+	}
+	if resp.VirtualMachineExtensionProperties == nil {
+		return nil
+	}
+
+	return virtualMachineExtensionInstanceViewError(resp.VirtualMachineExtensionProperties.InstanceView)
+}
+
 func virtualMachineExtensionsRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	vmExtensionClient := meta.(*clients.Client).Compute.VMExtensionClient
 	vmClient := meta.(*clients.Client).Compute.VMClient
@@ -195,7 +232,7 @@ func virtualMachineExtensionsRead(d *pluginsdk.ResourceData, meta interface{}) e
 
 	d.Set("virtual_machine_id", virtualMachine.ID)
 
-	resp, err := vmExtensionClient.Get(ctx, id.ResourceGroup, id.VirtualMachineName, id.ExtensionName, "")
+	resp, err := vmExtensionClient.Get(ctx, id.ResourceGroup, id.VirtualMachineName, id.ExtensionName, "instanceView")
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			d.SetId("")
@@ -220,6 +257,10 @@ func virtualMachineExtensionsRead(d *pluginsdk.ResourceData, meta interface{}) e
 			}
 			d.Set("settings", settingsJson)
 		}
+
+		if err := d.Set("instance_view", flattenVirtualMachineExtensionInstanceView(props.InstanceView)); err != nil {
+			return fmt.Errorf("setting `instance_view`: %+v", err)
+		}
 	}
 
 	return tags.FlattenAndSet(d, resp.Tags)
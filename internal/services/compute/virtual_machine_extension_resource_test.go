@@ -26,9 +26,10 @@ func TestAccVirtualMachineExtension_basic(t *testing.T) {
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 				acceptance.TestMatchResourceAttr(data.ResourceName, "settings", regexp.MustCompile("hostname")),
+				check.That(data.ResourceName).Key("instance_view.0.statuses.0.code").Exists(),
 			),
 		},
-		data.ImportStep("protected_settings"),
+		data.ImportStep("protected_settings", "fail_on_error_status"),
 		{
 			Config: r.basicUpdate(data),
 			Check: acceptance.ComposeTestCheckFunc(
@@ -36,7 +37,7 @@ func TestAccVirtualMachineExtension_basic(t *testing.T) {
 				acceptance.TestMatchResourceAttr(data.ResourceName, "settings", regexp.MustCompile("whoami")),
 			),
 		},
-		data.ImportStep("protected_settings"),
+		data.ImportStep("protected_settings", "fail_on_error_status"),
 	})
 }
 
@@ -58,6 +59,39 @@ func TestAccVirtualMachineExtension_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccVirtualMachineExtension_typeHandlerVersionUnavailable(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_extension", "test")
+	r := VirtualMachineExtensionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			// the Virtual Machine needs to exist for its location to be known during the plan
+			Config:      r.typeHandlerVersionUnavailable(data),
+			ExpectError: regexp.MustCompile("is not available for the Extension Image"),
+		},
+	})
+}
+
+func TestAccVirtualMachineExtension_failOnErrorStatus(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_extension", "test")
+	r := VirtualMachineExtensionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			// the failing Extension is the only CustomScript Extension on the Virtual Machine, since only
+			// one Extension per handler can be installed
+			Config:      r.failOnErrorStatus(data),
+			ExpectError: regexp.MustCompile("the Extension reported an error status"),
+		},
+	})
+}
+
 func TestAccVirtualMachineExtension_concurrent(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_extension", "test")
 	r := VirtualMachineExtensionResource{}
@@ -89,7 +123,32 @@ func (t VirtualMachineExtensionResource) Exists(ctx context.Context, clients *cl
 	return pointer.FromBool(resp.ID != nil), nil
 }
 
-func (VirtualMachineExtensionResource) basic(data acceptance.TestData) string {
+func (r VirtualMachineExtensionResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_extension" "test" {
+  name                 = "acctvme-%d"
+  virtual_machine_id   = azurestack_virtual_machine.test.id
+  publisher            = "Microsoft.Azure.Extensions"
+  type                 = "CustomScript"
+  type_handler_version = "2.0"
+
+  settings = <<SETTINGS
+	{
+		"commandToExecute": "hostname"
+	}
+SETTINGS
+
+
+  tags = {
+    environment = "Production"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (VirtualMachineExtensionResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurestack" {
   features {}
@@ -175,26 +234,7 @@ resource "azurestack_virtual_machine" "test" {
     disable_password_authentication = false
   }
 }
-
-resource "azurestack_virtual_machine_extension" "test" {
-  name                 = "acctvme-%d"
-  virtual_machine_id   = azurestack_virtual_machine.test.id
-  publisher            = "Microsoft.Azure.Extensions"
-  type                 = "CustomScript"
-  type_handler_version = "2.0"
-
-  settings = <<SETTINGS
-	{
-		"commandToExecute": "hostname"
-	}
-SETTINGS
-
-
-  tags = {
-    environment = "Production"
-  }
-}
-`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger, data.RandomInteger)
 }
 
 func (r VirtualMachineExtensionResource) requiresImport(data acceptance.TestData) string {
@@ -213,6 +253,41 @@ resource "azurestack_virtual_machine_extension" "import" {
 `, r.basic(data))
 }
 
+func (r VirtualMachineExtensionResource) typeHandlerVersionUnavailable(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_extension" "unavailable" {
+  name                 = "acctvme-%d-2"
+  virtual_machine_id   = azurestack_virtual_machine.test.id
+  publisher            = "Microsoft.Azure.Extensions"
+  type                 = "CustomScript"
+  type_handler_version = "99.0"
+}
+`, r.basic(data), data.RandomInteger)
+}
+
+func (r VirtualMachineExtensionResource) failOnErrorStatus(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_extension" "test" {
+  name                 = "acctvme-%d"
+  virtual_machine_id   = azurestack_virtual_machine.test.id
+  publisher            = "Microsoft.Azure.Extensions"
+  type                 = "CustomScript"
+  type_handler_version = "2.0"
+  fail_on_error_status = true
+
+  settings = <<SETTINGS
+	{
+		"commandToExecute": "echo 'configuration failed' >&2 && exit 1"
+	}
+SETTINGS
+}
+`, r.template(data), data.RandomInteger)
+}
+
 func (VirtualMachineExtensionResource) basicUpdate(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurestack" {
@@ -0,0 +1,248 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualMachineImageCapture() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: virtualMachineImageCaptureCreate,
+		Read:   virtualMachineImageCaptureRead,
+		Delete: virtualMachineImageCaptureDelete,
+
+		// the capture of an Image can't be imported, since it's a workflow rather than a resource - an existing
+		// Image can be imported using the `azurestack_image` resource instead
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(90 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(90 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupName(),
+
+			"source_virtual_machine_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.VirtualMachineID,
+			},
+
+			"delete_source_virtual_machine": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"tags": tags.ForceNewSchema(),
+
+			"location": commonschema.LocationComputed(),
+
+			"captured_at": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"os_type": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"os_disk_size_gb": {
+				Type:     pluginsdk.TypeInt,
+				Computed: true,
+			},
+
+			"data_disk_count": {
+				Type:     pluginsdk.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func virtualMachineImageCaptureCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	imagesClient := meta.(*clients.Client).Compute.ImageClient
+	vmClient := meta.(*clients.Client).Compute.VMClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewImageID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+	virtualMachineId, err := parse.VirtualMachineID(d.Get("source_virtual_machine_id").(string))
+	if err != nil {
+		return err
+	}
+
+	existing, err := imagesClient.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return fmt.Errorf("%s already exists - the Image can't be captured into an existing Image, as such this needs to be removed or a different `name` specified", id)
+	}
+
+	locks.ByName(virtualMachineId.Name, virtualMachineResourceName)
+	defer locks.UnlockByName(virtualMachineId.Name, virtualMachineResourceName)
+
+	virtualMachine, err := vmClient.Get(ctx, virtualMachineId.ResourceGroup, virtualMachineId.Name, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *virtualMachineId, err)
+	}
+
+	instanceView, err := vmClient.InstanceView(ctx, virtualMachineId.ResourceGroup, virtualMachineId.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving InstanceView for %s: %+v", *virtualMachineId, err)
+	}
+	powerState := normalizeVirtualMachinePowerState(virtualMachinePowerStateFromStatuses(instanceView.Statuses))
+
+	// the Virtual Machine must be deallocated before it can be generalized
+	if powerState != virtualMachinePowerStateDeallocated {
+		log.Printf("[DEBUG] Deallocating %s prior to capturing %s..", *virtualMachineId, id)
+		if err := convergeVirtualMachinePowerState(ctx, vmClient, *virtualMachineId, powerState, virtualMachinePowerStateDeallocated, nil); err != nil {
+			return fmt.Errorf("deallocating %s: %+v", *virtualMachineId, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Generalizing %s..", *virtualMachineId)
+	if _, err := vmClient.Generalize(ctx, virtualMachineId.ResourceGroup, virtualMachineId.Name); err != nil {
+		return fmt.Errorf("generalizing %s: %+v", *virtualMachineId, err)
+	}
+
+	parameters := compute.Image{
+		Location: utils.String(location.NormalizeNilable(virtualMachine.Location)),
+		Tags:     tags.Expand(d.Get("tags").(map[string]interface{})),
+		ImageProperties: &compute.ImageProperties{
+			SourceVirtualMachine: &compute.SubResource{
+				ID: utils.String(virtualMachineId.ID()),
+			},
+		},
+	}
+
+	log.Printf("[DEBUG] Capturing %s from %s..", id, *virtualMachineId)
+	future, err := imagesClient.CreateOrUpdate(ctx, id.ResourceGroup, id.Name, parameters)
+	if err != nil {
+		return fmt.Errorf("capturing %s from %s: %+v", id, *virtualMachineId, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, imagesClient.Client); err != nil {
+		return fmt.Errorf("waiting for the capture of %s from %s: %+v", id, *virtualMachineId, err)
+	}
+
+	d.SetId(id.ID())
+	d.Set("captured_at", time.Now().UTC().Format(time.RFC3339))
+
+	if d.Get("delete_source_virtual_machine").(bool) {
+		log.Printf("[DEBUG] Deleting the source %s..", *virtualMachineId)
+		deleteFuture, err := vmClient.Delete(ctx, virtualMachineId.ResourceGroup, virtualMachineId.Name, nil)
+		if err != nil {
+			return fmt.Errorf("deleting the source %s: %+v", *virtualMachineId, err)
+		}
+		if err := deleteFuture.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
+			return fmt.Errorf("waiting for the deletion of the source %s: %+v", *virtualMachineId, err)
+		}
+		log.Printf("[DEBUG] Deleted the source %s.", *virtualMachineId)
+	}
+
+	return virtualMachineImageCaptureRead(d, meta)
+}
+
+func virtualMachineImageCaptureRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.ImageClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ImageID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("location", location.NormalizeNilable(resp.Location))
+
+	osType := ""
+	osDiskSizeGb := 0
+	dataDiskCount := 0
+	if props := resp.ImageProperties; props != nil {
+		// the casing of the Source Virtual Machine ID returned from the API can differ from the Virtual Machine
+		if props.SourceVirtualMachine != nil && props.SourceVirtualMachine.ID != nil && !strings.EqualFold(*props.SourceVirtualMachine.ID, d.Get("source_virtual_machine_id").(string)) {
+			d.Set("source_virtual_machine_id", props.SourceVirtualMachine.ID)
+		}
+
+		if profile := props.StorageProfile; profile != nil {
+			if osDisk := profile.OsDisk; osDisk != nil {
+				osType = string(osDisk.OsType)
+				if osDisk.DiskSizeGB != nil {
+					osDiskSizeGb = int(*osDisk.DiskSizeGB)
+				}
+			}
+			if profile.DataDisks != nil {
+				dataDiskCount = len(*profile.DataDisks)
+			}
+		}
+	}
+	d.Set("os_type", osType)
+	d.Set("os_disk_size_gb", osDiskSizeGb)
+	d.Set("data_disk_count", dataDiskCount)
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func virtualMachineImageCaptureDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.ImageClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ImageID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+	}
+
+	return nil
+}
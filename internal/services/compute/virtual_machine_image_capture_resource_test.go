@@ -0,0 +1,95 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type VirtualMachineImageCaptureResource struct{}
+
+func TestAccVirtualMachineImageCapture_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_image_capture", "test")
+	r := VirtualMachineImageCaptureResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("captured_at").Exists(),
+				check.That(data.ResourceName).Key("os_type").HasValue("Linux"),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("Production"),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineImageCapture_existingImage(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_image_capture", "test")
+	r := VirtualMachineImageCaptureResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.existingImage(data),
+			ExpectError: regexp.MustCompile("already exists - the Image can't be captured into an existing Image"),
+		},
+	})
+}
+
+func (VirtualMachineImageCaptureResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ImageID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.ImageClient.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (VirtualMachineImageCaptureResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_image_capture" "test" {
+  name                      = "acctestimg-%d"
+  resource_group_name       = azurestack_resource_group.test.name
+  source_virtual_machine_id = azurestack_linux_virtual_machine.test.id
+
+  tags = {
+    environment = "Production"
+  }
+}
+`, LinuxVirtualMachineResource{}.authSSH(data), data.RandomInteger)
+}
+
+func (r VirtualMachineImageCaptureResource) existingImage(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_image_capture" "import" {
+  name                      = azurestack_virtual_machine_image_capture.test.name
+  resource_group_name       = azurestack_virtual_machine_image_capture.test.resource_group_name
+  source_virtual_machine_id = azurestack_virtual_machine_image_capture.test.source_virtual_machine_id
+}
+`, r.basic(data))
+}
@@ -1,9 +1,12 @@
 package compute
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
 )
 
 // virtualMachineShouldBeStarted determines if the Virtual Machine should be started after
@@ -31,3 +34,41 @@ func virtualMachineShouldBeStarted(instanceView compute.VirtualMachineInstanceVi
 
 	return false
 }
+
+// virtualMachinePowerStateFromStatuses returns the Power State (e.g. `running` or `deallocated`) of a
+// Virtual Machine or Scale Set instance from the statuses in its Instance View, or an empty
+// string when this isn't available
+func virtualMachinePowerStateFromStatuses(statuses *[]compute.InstanceViewStatus) string {
+	if statuses == nil {
+		return ""
+	}
+
+	for _, status := range *statuses {
+		if status.Code == nil {
+			continue
+		}
+
+		state := strings.ToLower(*status.Code)
+		if strings.HasPrefix(state, "powerstate/") {
+			return strings.TrimPrefix(state, "powerstate/")
+		}
+	}
+
+	return ""
+}
+
+// virtualMachinePowerOff powers off the Virtual Machine and waits for this to complete, using `description`
+// to refer to the Virtual Machine in any errors. When skipShutdown is nil the API default is used, which
+// shuts down the Operating System gracefully
+func virtualMachinePowerOff(ctx context.Context, client *compute.VirtualMachinesClient, id parse.VirtualMachineId, skipShutdown *bool, description string) error {
+	future, err := client.PowerOff(ctx, id.ResourceGroup, id.Name, skipShutdown)
+	if err != nil {
+		return fmt.Errorf("powering off %s: %+v", description, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for power off of %s: %+v", description, err)
+	}
+
+	return nil
+}
@@ -79,3 +79,68 @@ func TestVirtualMachineShouldBeStarted(t *testing.T) {
 		}
 	}
 }
+
+func TestVirtualMachinePowerStateFromStatuses(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    *[]compute.InstanceViewStatus
+		Expected string
+	}{
+		{
+			Name:     "None",
+			Input:    nil,
+			Expected: "",
+		},
+		{
+			Name: "No Power State",
+			Input: &[]compute.InstanceViewStatus{
+				{Code: utils.String("ProvisioningState/creating")},
+			},
+			Expected: "",
+		},
+		{
+			Name: "Running",
+			Input: &[]compute.InstanceViewStatus{
+				{Code: utils.String("ProvisioningState/succeeded")},
+				{Code: utils.String("PowerState/running")},
+			},
+			Expected: "running",
+		},
+		{
+			Name: "Deallocated",
+			Input: &[]compute.InstanceViewStatus{
+				{Code: utils.String("ProvisioningState/succeeded")},
+				{Code: utils.String("PowerState/Deallocated")},
+			},
+			Expected: "deallocated",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+
+		result := virtualMachinePowerStateFromStatuses(testCase.Input)
+		if result != testCase.Expected {
+			t.Fatalf("Expected %q but got %q", testCase.Expected, result)
+		}
+	}
+}
+
+func TestNormalizeVirtualMachinePowerState(t *testing.T) {
+	testCases := map[string]string{
+		"":             "",
+		"deallocated":  "deallocated",
+		"deallocating": "deallocated",
+		"running":      "running",
+		"starting":     "running",
+		"stopped":      "stopped",
+		"stopping":     "stopped",
+		"unknown":      "unknown",
+	}
+
+	for input, expected := range testCases {
+		if actual := normalizeVirtualMachinePowerState(input); actual != expected {
+			t.Fatalf("Expected %q for %q but got %q", expected, input, actual)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package compute
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualMachineManagedDiskConversion() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: virtualMachineManagedDiskConversionCreate,
+		Read:   virtualMachineManagedDiskConversionRead,
+		Delete: virtualMachineManagedDiskConversionDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.VirtualMachineID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"virtual_machine_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.VirtualMachineID,
+			},
+
+			"os_managed_disk_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"data_managed_disk_ids": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func virtualMachineManagedDiskConversionCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineID(d.Get("virtual_machine_id").(string))
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(id.Name, virtualMachineResourceName)
+	defer locks.UnlockByName(id.Name, virtualMachineResourceName)
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if !virtualMachineUsesUnmanagedDisks(existing.VirtualMachineProperties) {
+		log.Printf("[DEBUG] %s already uses Managed Disks - skipping conversion", *id)
+		d.SetId(id.ID())
+		return virtualMachineManagedDiskConversionRead(d, meta)
+	}
+
+	instanceView, err := client.InstanceView(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving InstanceView for %s: %+v", *id, err)
+	}
+	powerState := normalizeVirtualMachinePowerState(virtualMachinePowerStateFromStatuses(instanceView.Statuses))
+
+	// the Virtual Machine must be deallocated before it can be converted
+	if powerState != virtualMachinePowerStateDeallocated {
+		log.Printf("[DEBUG] Deallocating %s prior to converting to Managed Disks..", *id)
+		if err := convergeVirtualMachinePowerState(ctx, client, *id, powerState, virtualMachinePowerStateDeallocated, nil); err != nil {
+			return fmt.Errorf("deallocating %s: %+v", *id, err)
+		}
+	}
+
+	log.Printf("[DEBUG] Converting %s to Managed Disks..", *id)
+	future, err := client.ConvertToManagedDisks(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("converting %s to Managed Disks: %+v", *id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the conversion of %s to Managed Disks: %+v", *id, err)
+	}
+	log.Printf("[DEBUG] Converted %s to Managed Disks.", *id)
+
+	d.SetId(id.ID())
+
+	// the Virtual Machine is returned to the Power State it was in prior to the conversion
+	if powerState == virtualMachinePowerStateRunning {
+		instanceView, err := client.InstanceView(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return fmt.Errorf("retrieving InstanceView for %s: %+v", *id, err)
+		}
+
+		current := normalizeVirtualMachinePowerState(virtualMachinePowerStateFromStatuses(instanceView.Statuses))
+		if current != virtualMachinePowerStateRunning {
+			log.Printf("[DEBUG] Starting %s following the conversion to Managed Disks..", *id)
+			if err := virtualMachineStart(ctx, client, *id); err != nil {
+				return fmt.Errorf("starting %s: %+v", *id, err)
+			}
+		}
+	}
+
+	return virtualMachineManagedDiskConversionRead(d, meta)
+}
+
+func virtualMachineManagedDiskConversionRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing Managed Disk Conversion from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	// a Virtual Machine recreated using Unmanaged Disks needs to be converted again
+	if virtualMachineUsesUnmanagedDisks(resp.VirtualMachineProperties) {
+		log.Printf("[DEBUG] %s uses Unmanaged Disks - removing Managed Disk Conversion from state", *id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("virtual_machine_id", id.ID())
+
+	osManagedDiskId := ""
+	dataManagedDiskIds := make([]interface{}, 0)
+	if props := resp.VirtualMachineProperties; props != nil && props.StorageProfile != nil {
+		if osDisk := props.StorageProfile.OsDisk; osDisk != nil && osDisk.ManagedDisk != nil && osDisk.ManagedDisk.ID != nil {
+			osManagedDiskId = *osDisk.ManagedDisk.ID
+		}
+
+		if dataDisks := props.StorageProfile.DataDisks; dataDisks != nil {
+			for _, dataDisk := range *dataDisks {
+				if dataDisk.ManagedDisk != nil && dataDisk.ManagedDisk.ID != nil {
+					dataManagedDiskIds = append(dataManagedDiskIds, *dataDisk.ManagedDisk.ID)
+				}
+			}
+		}
+	}
+	d.Set("os_managed_disk_id", osManagedDiskId)
+	d.Set("data_managed_disk_ids", dataManagedDiskIds)
+
+	return nil
+}
+
+func virtualMachineManagedDiskConversionDelete(d *pluginsdk.ResourceData, _ interface{}) error {
+	// the conversion to Managed Disks can't be reversed, as such the Virtual Machine is unchanged
+	log.Printf("[DEBUG] Removing the Managed Disk Conversion for Virtual Machine %q from state - the Virtual Machine itself is unchanged", d.Id())
+	return nil
+}
+
+// virtualMachineUsesUnmanagedDisks returns whether the OS Disk or any of the Data Disks are Unmanaged Disks
+func virtualMachineUsesUnmanagedDisks(props *compute.VirtualMachineProperties) bool {
+	if props == nil || props.StorageProfile == nil {
+		return false
+	}
+
+	if osDisk := props.StorageProfile.OsDisk; osDisk != nil && osDisk.Vhd != nil {
+		return true
+	}
+
+	if dataDisks := props.StorageProfile.DataDisks; dataDisks != nil {
+		for _, dataDisk := range *dataDisks {
+			if dataDisk.Vhd != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,167 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type VirtualMachineManagedDiskConversionResource struct{}
+
+func TestAccVirtualMachineManagedDiskConversion_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_managed_disk_conversion", "test")
+	r := VirtualMachineManagedDiskConversionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("os_managed_disk_id").Exists(),
+				check.That(data.ResourceName).Key("data_managed_disk_ids.#").HasValue("1"),
+			),
+		},
+		{
+			// the `vhd_uri` fields can then be removed from the Virtual Machine without any changes
+			Config: r.basic(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurestack_virtual_machine.test").Key("storage_os_disk.0.vhd_uri").HasValue(""),
+				check.That("azurestack_virtual_machine.test").Key("storage_os_disk.0.managed_disk_id").Exists(),
+				check.That("azurestack_virtual_machine.test").Key("storage_data_disk.0.managed_disk_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (VirtualMachineManagedDiskConversionResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.VirtualMachineID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.VMClient.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if props := resp.VirtualMachineProperties; props != nil && props.StorageProfile != nil && props.StorageProfile.OsDisk != nil {
+		osDisk := props.StorageProfile.OsDisk
+		return pointer.FromBool(osDisk.Vhd == nil && osDisk.ManagedDisk != nil), nil
+	}
+
+	return pointer.FromBool(false), nil
+}
+
+func (VirtualMachineManagedDiskConversionResource) basic(data acceptance.TestData, unmanaged bool) string {
+	osDiskVhdUri := ""
+	dataDiskVhdUri := ""
+	if unmanaged {
+		osDiskVhdUri = "    vhd_uri       = \"${azurestack_storage_account.test.primary_blob_endpoint}${azurestack_storage_container.test.name}/myosdisk1.vhd\"\n"
+		dataDiskVhdUri = "    vhd_uri       = \"${azurestack_storage_account.test.primary_blob_endpoint}${azurestack_storage_container.test.name}/mydatadisk1.vhd\"\n"
+	}
+
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctvn-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "acctsub-%[1]d"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurestack_network_interface" "test" {
+  name                = "acctni-%[1]d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  ip_configuration {
+    name                          = "testconfiguration1"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurestack_storage_account" "test" {
+  name                     = "accsa%[1]d"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurestack_storage_container" "test" {
+  name                  = "vhds"
+  storage_account_name  = azurestack_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurestack_virtual_machine" "test" {
+  name                  = "acctvm-%[1]d"
+  location              = azurestack_resource_group.test.location
+  resource_group_name   = azurestack_resource_group.test.name
+  network_interface_ids = [azurestack_network_interface.test.id]
+  vm_size               = "Standard_D1_v2"
+
+  delete_os_disk_on_termination    = true
+  delete_data_disks_on_termination = true
+
+  storage_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  storage_os_disk {
+    name          = "myosdisk1"
+%[3]s    caching       = "ReadWrite"
+    create_option = "FromImage"
+  }
+
+  storage_data_disk {
+    name          = "mydatadisk1"
+%[4]s    disk_size_gb  = "1"
+    create_option = "Empty"
+    lun           = 0
+  }
+
+  os_profile {
+    computer_name  = "hn%[1]d"
+    admin_username = "testadmin"
+    admin_password = "Password1234!"
+  }
+
+  os_profile_linux_config {
+    disable_password_authentication = false
+  }
+}
+
+resource "azurestack_virtual_machine_managed_disk_conversion" "test" {
+  virtual_machine_id = azurestack_virtual_machine.test.id
+}
+`, data.RandomInteger, data.Locations.Primary, osDiskVhdUri, dataDiskVhdUri)
+}
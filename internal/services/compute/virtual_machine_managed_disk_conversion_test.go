@@ -0,0 +1,82 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestVirtualMachineUsesUnmanagedDisks(t *testing.T) {
+	managedOsDisk := &compute.OSDisk{
+		ManagedDisk: &compute.ManagedDiskParameters{
+			ID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/disks/osdisk1"),
+		},
+	}
+
+	testCases := []struct {
+		Name     string
+		Input    *compute.VirtualMachineProperties
+		Expected bool
+	}{
+		{
+			Name:     "None",
+			Input:    nil,
+			Expected: false,
+		},
+		{
+			Name: "Managed Disks",
+			Input: &compute.VirtualMachineProperties{
+				StorageProfile: &compute.StorageProfile{
+					OsDisk: managedOsDisk,
+					DataDisks: &[]compute.DataDisk{
+						{
+							ManagedDisk: &compute.ManagedDiskParameters{},
+						},
+					},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "Unmanaged OS Disk",
+			Input: &compute.VirtualMachineProperties{
+				StorageProfile: &compute.StorageProfile{
+					OsDisk: &compute.OSDisk{
+						Vhd: &compute.VirtualHardDisk{
+							URI: utils.String("https://example.blob.local.azurestack.external/vhds/osdisk1.vhd"),
+						},
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name: "Unmanaged Data Disk",
+			Input: &compute.VirtualMachineProperties{
+				StorageProfile: &compute.StorageProfile{
+					OsDisk: managedOsDisk,
+					DataDisks: &[]compute.DataDisk{
+						{
+							ManagedDisk: &compute.ManagedDiskParameters{},
+						},
+						{
+							Vhd: &compute.VirtualHardDisk{
+								URI: utils.String("https://example.blob.local.azurestack.external/vhds/datadisk1.vhd"),
+							},
+						},
+					},
+				},
+			},
+			Expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+
+		if actual := virtualMachineUsesUnmanagedDisks(testCase.Input); actual != testCase.Expected {
+			t.Fatalf("Expected %t but got %t", testCase.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+const (
+	virtualMachinePowerStateDeallocated = "deallocated"
+	virtualMachinePowerStateRunning     = "running"
+	virtualMachinePowerStateStopped     = "stopped"
+)
+
+func virtualMachinePowerState() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: virtualMachinePowerStateCreateUpdate,
+		Read:   virtualMachinePowerStateRead,
+		Update: virtualMachinePowerStateCreateUpdate,
+		Delete: virtualMachinePowerStateDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.VirtualMachineID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"virtual_machine_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.VirtualMachineID,
+			},
+
+			"power_state": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					virtualMachinePowerStateDeallocated,
+					virtualMachinePowerStateRunning,
+					virtualMachinePowerStateStopped,
+				}, false),
+			},
+		},
+	}
+}
+
+func virtualMachinePowerStateCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineID(d.Get("virtual_machine_id").(string))
+	if err != nil {
+		return err
+	}
+
+	locks.ByName(id.Name, virtualMachineResourceName)
+	defer locks.UnlockByName(id.Name, virtualMachineResourceName)
+
+	instanceView, err := client.InstanceView(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving InstanceView for %s: %+v", *id, err)
+	}
+
+	current := normalizeVirtualMachinePowerState(virtualMachinePowerStateFromStatuses(instanceView.Statuses))
+	desired := d.Get("power_state").(string)
+
+	if current != desired {
+		// the graceful_shutdown feature determines whether the Operating System is shut down before powering off
+		skipShutdown := utils.Bool(!meta.(*clients.Client).Features.VirtualMachine.GracefulShutdown)
+
+		log.Printf("[DEBUG] Changing the Power State of %s from %q to %q..", *id, current, desired)
+		if err := convergeVirtualMachinePowerState(ctx, client, *id, current, desired, skipShutdown); err != nil {
+			return fmt.Errorf("changing the Power State of %s from %q to %q: %+v", *id, current, desired, err)
+		}
+		log.Printf("[DEBUG] Changed the Power State of %s to %q.", *id, desired)
+	}
+
+	d.SetId(id.ID())
+
+	return virtualMachinePowerStateRead(d, meta)
+}
+
+func virtualMachinePowerStateRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	instanceView, err := client.InstanceView(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(instanceView.Response) {
+			log.Printf("[DEBUG] %s was not found - removing Power State from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving InstanceView for %s: %+v", *id, err)
+	}
+
+	d.Set("virtual_machine_id", id.ID())
+
+	// the Power State can be unavailable (or `unknown`) whilst the Virtual Machine is being updated, in
+	// which case the last known value is kept rather than surfacing a diff which can't be applied
+	powerState := normalizeVirtualMachinePowerState(virtualMachinePowerStateFromStatuses(instanceView.Statuses))
+	if isSupportedVirtualMachinePowerState(powerState) {
+		d.Set("power_state", powerState)
+	} else {
+		log.Printf("[DEBUG] the Power State of %s is %q - keeping the last known Power State", *id, powerState)
+	}
+
+	return nil
+}
+
+func virtualMachinePowerStateDelete(d *pluginsdk.ResourceData, _ interface{}) error {
+	// the Virtual Machine is intentionally left in whichever Power State it's currently in
+	log.Printf("[DEBUG] Removing the Power State for Virtual Machine %q from state - the Virtual Machine itself is unchanged", d.Id())
+	return nil
+}
+
+// normalizeVirtualMachinePowerState maps the transitional Power States onto the Power State
+// the Virtual Machine is transitioning to, so that these aren't surfaced as a diff
+func normalizeVirtualMachinePowerState(input string) string {
+	switch input {
+	case "starting":
+		return virtualMachinePowerStateRunning
+	case "stopping":
+		return virtualMachinePowerStateStopped
+	case "deallocating":
+		return virtualMachinePowerStateDeallocated
+	}
+
+	return input
+}
+
+func isSupportedVirtualMachinePowerState(input string) bool {
+	switch input {
+	case virtualMachinePowerStateDeallocated, virtualMachinePowerStateRunning, virtualMachinePowerStateStopped:
+		return true
+	}
+
+	return false
+}
+
+func convergeVirtualMachinePowerState(ctx context.Context, client *compute.VirtualMachinesClient, id parse.VirtualMachineId, current, desired string, skipShutdown *bool) error {
+	switch desired {
+	case virtualMachinePowerStateRunning:
+		return virtualMachineStart(ctx, client, id)
+
+	case virtualMachinePowerStateStopped:
+		// a Virtual Machine can only be Powered Off once it's running, so a deallocated
+		// Virtual Machine needs to be started (and allocated) first
+		if current == virtualMachinePowerStateDeallocated {
+			if err := virtualMachineStart(ctx, client, id); err != nil {
+				return err
+			}
+		}
+
+		return virtualMachinePowerOff(ctx, client, id, skipShutdown, "the Virtual Machine")
+
+	case virtualMachinePowerStateDeallocated:
+		future, err := client.Deallocate(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return fmt.Errorf("deallocating: %+v", err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for deallocation: %+v", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unsupported Power State %q", desired)
+}
+
+func virtualMachineStart(ctx context.Context, client *compute.VirtualMachinesClient, id parse.VirtualMachineId) error {
+	future, err := client.Start(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("starting: %+v", err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for start: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type VirtualMachinePowerStateResource struct{}
+
+func TestAccVirtualMachinePowerState_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_power_state", "test")
+	r := VirtualMachinePowerStateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "deallocated"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("deallocated"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccVirtualMachinePowerState_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_power_state", "test")
+	r := VirtualMachinePowerStateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "stopped"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("stopped"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, "deallocated"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("deallocated"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, "running"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("power_state").HasValue("running"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (VirtualMachinePowerStateResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.VirtualMachineID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.VMClient.InstanceView(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving InstanceView for %s: %+v", *id, err)
+	}
+
+	if resp.Statuses != nil {
+		for _, status := range *resp.Statuses {
+			if status.Code != nil && strings.HasPrefix(strings.ToLower(*status.Code), "powerstate/") {
+				return pointer.FromBool(true), nil
+			}
+		}
+	}
+
+	return pointer.FromBool(false), nil
+}
+
+func (VirtualMachinePowerStateResource) basic(data acceptance.TestData, powerState string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_power_state" "test" {
+  virtual_machine_id = azurestack_linux_virtual_machine.test.id
+  power_state        = "%s"
+}
+`, LinuxVirtualMachineResource{}.authSSH(data), powerState)
+}
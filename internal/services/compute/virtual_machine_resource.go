@@ -37,6 +37,18 @@ func userDataDiffSuppressFunc(_, old, new string, _ *pluginsdk.ResourceData) boo
 	return userDataStateFunc(old) == new
 }
 
+// virtualMachineVhdUriDiffSuppressFunc suppresses the diff for a `vhd_uri` which is no longer returned since
+// the disk has been converted to a Managed Disk (e.g. by `azurestack_virtual_machine_managed_disk_conversion`),
+// so that the Virtual Machine isn't replaced when the configuration still specifies the original VHD
+func virtualMachineVhdUriDiffSuppressFunc(k, old, new string, d *pluginsdk.ResourceData) bool {
+	if old != "" || new == "" {
+		return false
+	}
+
+	managedDiskIdKey := strings.TrimSuffix(k, "vhd_uri") + "managed_disk_id"
+	return d.Get(managedDiskIdKey).(string) != ""
+}
+
 func userDataStateFunc(v interface{}) string {
 	switch s := v.(type) {
 	case string:
@@ -226,9 +238,10 @@ func virtualMachine() *pluginsdk.Resource {
 						},
 
 						"vhd_uri": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
-							ForceNew: true,
+							Type:             pluginsdk.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: virtualMachineVhdUriDiffSuppressFunc,
 							ConflictsWith: []string{
 								"storage_os_disk.0.managed_disk_id",
 								"storage_os_disk.0.managed_disk_type",
@@ -306,8 +319,9 @@ func virtualMachine() *pluginsdk.Resource {
 						},
 
 						"vhd_uri": {
-							Type:     pluginsdk.TypeString,
-							Optional: true,
+							Type:             pluginsdk.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: virtualMachineVhdUriDiffSuppressFunc,
 						},
 
 						"managed_disk_id": {
@@ -0,0 +1,374 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/storage"
+	intStor "github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/client"
+	storageValidate "github.com/hashicorp/terraform-provider-azurestack/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2018-11-09/blob/blobs"
+)
+
+// virtualMachineRunScriptHandler describes the CustomScript Extension used to run a script on a given Operating System
+type virtualMachineRunScriptHandler struct {
+	publisher          string
+	extensionType      string
+	typeHandlerVersion string
+	fileExtension      string
+	command            string
+}
+
+var virtualMachineRunScriptHandlers = map[compute.OperatingSystemTypes]virtualMachineRunScriptHandler{
+	compute.Linux: {
+		publisher:          "Microsoft.Azure.Extensions",
+		extensionType:      "CustomScript",
+		typeHandlerVersion: "2.0",
+		fileExtension:      "sh",
+		command:            "/bin/sh %s",
+	},
+	compute.Windows: {
+		publisher:          "Microsoft.Compute",
+		extensionType:      "CustomScriptExtension",
+		typeHandlerVersion: "1.9",
+		fileExtension:      "ps1",
+		command:            "powershell.exe -ExecutionPolicy Unrestricted -File %s",
+	},
+}
+
+func virtualMachineRunScript() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: virtualMachineRunScriptCreate,
+		Read:   virtualMachineRunScriptRead,
+		Delete: virtualMachineRunScriptDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(90 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"virtual_machine_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.VirtualMachineID,
+			},
+
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: storageValidate.StorageAccountName,
+			},
+
+			"storage_container_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: storageValidate.StorageContainerName,
+			},
+
+			"script": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"triggers": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"script_blob_url": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"stdout": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"stderr": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"instance_view": virtualMachineExtensionInstanceViewSchema(),
+		},
+	}
+}
+
+func virtualMachineRunScriptCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	vmClient := meta.(*clients.Client).Compute.VMClient
+	extensionsClient := meta.(*clients.Client).Compute.VMExtensionClient
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	virtualMachineId, err := parse.VirtualMachineID(d.Get("virtual_machine_id").(string))
+	if err != nil {
+		return err
+	}
+	id := parse.NewVirtualMachineExtensionID(virtualMachineId.SubscriptionId, virtualMachineId.ResourceGroup, virtualMachineId.Name, d.Get("name").(string))
+
+	virtualMachine, err := vmClient.Get(ctx, id.ResourceGroup, id.VirtualMachineName, "")
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *virtualMachineId, err)
+	}
+	if virtualMachine.Location == nil || *virtualMachine.Location == "" {
+		return fmt.Errorf("reading location of %s", *virtualMachineId)
+	}
+
+	osType := compute.OperatingSystemTypes("")
+	if props := virtualMachine.VirtualMachineProperties; props != nil && props.StorageProfile != nil && props.StorageProfile.OsDisk != nil {
+		osType = props.StorageProfile.OsDisk.OsType
+	}
+	handler, ok := virtualMachineRunScriptHandlers[osType]
+	if !ok {
+		return fmt.Errorf("determining the Operating System of %s: expected `Linux` or `Windows` but got %q", *virtualMachineId, string(osType))
+	}
+
+	existing, err := extensionsClient.Get(ctx, id.ResourceGroup, id.VirtualMachineName, id.ExtensionName, "")
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !utils.ResponseWasNotFound(existing.Response) {
+		return fmt.Errorf("an Extension named %q already exists on %s - this must be removed before a script can be run with this name", id.ExtensionName, *virtualMachineId)
+	}
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	blobName := fmt.Sprintf("%s-%s.%s", id.VirtualMachineName, id.ExtensionName, handler.fileExtension)
+
+	account, err := storageClient.FindAccount(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Blob %q (Container %q): %s", accountName, blobName, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("unable to locate Storage Account %q", accountName)
+	}
+
+	accountKey, err := account.AccountKey(ctx, *storageClient)
+	if err != nil {
+		return fmt.Errorf("retrieving the Access Key for Storage Account %q: %+v", accountName, err)
+	}
+
+	blobsClient, err := storageClient.BlobsClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Blobs Client: %s", err)
+	}
+
+	log.Printf("[DEBUG] Uploading the script for %s to Blob %q (Container %q / Account %q)..", id, blobName, containerName, accountName)
+	upload := storage.BlobUpload{
+		Client:        blobsClient,
+		AccountName:   accountName,
+		ContainerName: containerName,
+		BlobName:      blobName,
+
+		BlobType:      "block",
+		ContentType:   "text/plain",
+		SourceContent: d.Get("script").(string),
+	}
+	if err := upload.Create(ctx); err != nil {
+		return fmt.Errorf("uploading the script to Blob %q (Container %q / Account %q): %+v", blobName, containerName, accountName, err)
+	}
+	blobUrl := blobsClient.GetResourceID(accountName, containerName, blobName)
+
+	settings := map[string]interface{}{
+		"fileUris": []interface{}{blobUrl},
+	}
+	protectedSettings := map[string]interface{}{
+		"commandToExecute":   fmt.Sprintf(handler.command, blobName),
+		"storageAccountName": accountName,
+		"storageAccountKey":  *accountKey,
+	}
+	extension := compute.VirtualMachineExtension{
+		Location: virtualMachine.Location,
+		VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{
+			Publisher:               utils.String(handler.publisher),
+			Type:                    utils.String(handler.extensionType),
+			TypeHandlerVersion:      utils.String(handler.typeHandlerVersion),
+			AutoUpgradeMinorVersion: utils.Bool(true),
+			Settings:                &settings,
+			ProtectedSettings:       &protectedSettings,
+		},
+	}
+
+	log.Printf("[DEBUG] Running the script on %s..", *virtualMachineId)
+	future, err := extensionsClient.CreateOrUpdate(ctx, id.ResourceGroup, id.VirtualMachineName, id.ExtensionName, extension)
+	if err != nil {
+		if _, deleteErr := blobsClient.Delete(ctx, accountName, containerName, blobName, blobs.DeleteInput{}); deleteErr != nil {
+			log.Printf("[DEBUG] deleting Blob %q (Container %q / Account %q): %+v", blobName, containerName, accountName, deleteErr)
+		}
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	// the ID is set prior to waiting so that a failed script is tainted, meaning that both the Extension and the
+	// Blob are removed before the script is run again
+	d.SetId(id.ID())
+	d.Set("script_blob_url", blobUrl)
+
+	if err := future.WaitForCompletionRef(ctx, extensionsClient.Client); err != nil {
+		if statusErr := virtualMachineExtensionStatusError(ctx, extensionsClient, id); statusErr != nil {
+			return fmt.Errorf("waiting for the script to run on %s: %+v\n\n%+v", *virtualMachineId, err, statusErr)
+		}
+		return fmt.Errorf("waiting for the script to run on %s: %+v", *virtualMachineId, err)
+	}
+
+	if err := virtualMachineExtensionStatusError(ctx, extensionsClient, id); err != nil {
+		return fmt.Errorf("running the script on %s: %+v", *virtualMachineId, err)
+	}
+	log.Printf("[DEBUG] Ran the script on %s.", *virtualMachineId)
+
+	return virtualMachineRunScriptRead(d, meta)
+}
+
+func virtualMachineRunScriptRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMExtensionClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineExtensionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineName, id.ExtensionName, "instanceView")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.ExtensionName)
+	d.Set("virtual_machine_id", parse.NewVirtualMachineID(id.SubscriptionId, id.ResourceGroup, id.VirtualMachineName).ID())
+
+	var instanceView *compute.VirtualMachineExtensionInstanceView
+	if props := resp.VirtualMachineExtensionProperties; props != nil {
+		instanceView = props.InstanceView
+	}
+
+	stdout, stderr := virtualMachineRunScriptOutput(instanceView)
+	d.Set("stdout", stdout)
+	d.Set("stderr", stderr)
+
+	if err := d.Set("instance_view", flattenVirtualMachineExtensionInstanceView(instanceView)); err != nil {
+		return fmt.Errorf("setting `instance_view`: %+v", err)
+	}
+
+	return nil
+}
+
+func virtualMachineRunScriptDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMExtensionClient
+	storageClient := meta.(*clients.Client).Storage
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineExtensionID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Deleting %s..", *id)
+	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualMachineName, id.ExtensionName)
+	if err != nil {
+		if !utils.WasNotFound(future.Response()) {
+			return fmt.Errorf("deleting %s: %+v", *id, err)
+		}
+	} else if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %+v", *id, err)
+	}
+
+	if v := d.Get("script_blob_url").(string); v != "" {
+		if err := virtualMachineRunScriptDeleteBlob(ctx, storageClient, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func virtualMachineRunScriptDeleteBlob(ctx context.Context, storageClient *intStor.Client, uri string) error {
+	id, err := blobs.ParseResourceID(uri)
+	if err != nil {
+		return fmt.Errorf("parsing %q: %s", uri, err)
+	}
+
+	account, err := storageClient.FindAccount(ctx, id.AccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Blob %q (Container %q): %s", id.AccountName, id.BlobName, id.ContainerName, err)
+	}
+	if account == nil {
+		log.Printf("[DEBUG] Storage Account %q was not found - assuming the Blob %q (Container %q) was removed", id.AccountName, id.BlobName, id.ContainerName)
+		return nil
+	}
+
+	blobsClient, err := storageClient.BlobsClient(ctx, *account)
+	if err != nil {
+		return fmt.Errorf("building Blobs Client: %s", err)
+	}
+
+	if resp, err := blobsClient.Delete(ctx, id.AccountName, id.ContainerName, id.BlobName, blobs.DeleteInput{}); err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("deleting Blob %q (Container %q / Account %q / Resource Group %q): %s", id.BlobName, id.ContainerName, id.AccountName, account.ResourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+// virtualMachineRunScriptOutput returns the StdOut and StdErr output of the script, which the CustomScript
+// Extensions report as substatuses within the Instance View
+func virtualMachineRunScriptOutput(input *compute.VirtualMachineExtensionInstanceView) (stdout string, stderr string) {
+	if input == nil || input.Substatuses == nil {
+		return
+	}
+
+	for _, v := range *input.Substatuses {
+		if v.Code == nil || v.Message == nil {
+			continue
+		}
+
+		code := strings.ToLower(*v.Code)
+		if strings.Contains(code, "/stdout/") {
+			stdout = *v.Message
+		}
+		if strings.Contains(code, "/stderr/") {
+			stderr = *v.Message
+		}
+	}
+
+	return
+}
@@ -0,0 +1,144 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type VirtualMachineRunScriptResource struct{}
+
+func TestAccVirtualMachineRunScript_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_run_script", "test")
+	r := VirtualMachineRunScriptResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("script_blob_url").Exists(),
+				check.That(data.ResourceName).Key("instance_view.0.statuses.0.code").Exists(),
+				acceptance.TestMatchResourceAttr(data.ResourceName, "stdout", regexp.MustCompile("hello from acctestVM")),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineRunScript_triggers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_run_script", "test")
+	r := VirtualMachineRunScriptResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("triggers.run").HasValue("first"),
+			),
+		},
+		{
+			Config: r.basic(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("triggers.run").HasValue("second"),
+				acceptance.TestMatchResourceAttr(data.ResourceName, "stdout", regexp.MustCompile("hello from acctestVM")),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineRunScript_failingScript(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_run_script", "test")
+	r := VirtualMachineRunScriptResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.failingScript(data),
+			ExpectError: regexp.MustCompile("the Extension reported an error status"),
+		},
+	})
+}
+
+func (VirtualMachineRunScriptResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.VirtualMachineExtensionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.VMExtensionClient.Get(ctx, id.ResourceGroup, id.VirtualMachineName, id.ExtensionName, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (VirtualMachineRunScriptResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurestack_resource_group.test.name
+  location                 = azurestack_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurestack_storage_container" "test" {
+  name                  = "scripts"
+  storage_account_name  = azurestack_storage_account.test.name
+  container_access_type = "private"
+}
+`, LinuxVirtualMachineResource{}.authSSH(data), data.RandomString)
+}
+
+func (r VirtualMachineRunScriptResource) basic(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_run_script" "test" {
+  name                   = "acctestrs-%d"
+  virtual_machine_id     = azurestack_linux_virtual_machine.test.id
+  storage_account_name   = azurestack_storage_account.test.name
+  storage_container_name = azurestack_storage_container.test.name
+
+  script = <<SCRIPT
+#!/bin/sh
+echo "hello from $(hostname)"
+SCRIPT
+
+  triggers = {
+    run = "%s"
+  }
+}
+`, r.template(data), data.RandomInteger, trigger)
+}
+
+func (r VirtualMachineRunScriptResource) failingScript(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_run_script" "test" {
+  name                   = "acctestrs-%d"
+  virtual_machine_id     = azurestack_linux_virtual_machine.test.id
+  storage_account_name   = azurestack_storage_account.test.name
+  storage_container_name = azurestack_storage_container.test.name
+
+  script = <<SCRIPT
+#!/bin/sh
+echo "configuration failed" >&2
+exit 1
+SCRIPT
+}
+`, r.template(data), data.RandomInteger)
+}
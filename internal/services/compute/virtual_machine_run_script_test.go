@@ -0,0 +1,70 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestVirtualMachineRunScriptOutput(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Input          *compute.VirtualMachineExtensionInstanceView
+		ExpectedStdOut string
+		ExpectedStdErr string
+	}{
+		{
+			Name:  "None",
+			Input: nil,
+		},
+		{
+			Name:  "No Substatuses",
+			Input: &compute.VirtualMachineExtensionInstanceView{},
+		},
+		{
+			Name: "Linux",
+			Input: &compute.VirtualMachineExtensionInstanceView{
+				Substatuses: &[]compute.InstanceViewStatus{
+					{
+						Code:    utils.String("ComponentStatus/StdOut/succeeded"),
+						Message: utils.String("hello world"),
+					},
+					{
+						Code:    utils.String("ComponentStatus/StdErr/succeeded"),
+						Message: utils.String("some warning"),
+					},
+				},
+			},
+			ExpectedStdOut: "hello world",
+			ExpectedStdErr: "some warning",
+		},
+		{
+			Name: "Windows",
+			Input: &compute.VirtualMachineExtensionInstanceView{
+				Substatuses: &[]compute.InstanceViewStatus{
+					{
+						Code:    utils.String("ComponentStatus/StdOut/succeeded"),
+						Message: utils.String("hello world"),
+					},
+					{
+						Code: utils.String("ComponentStatus/StdErr/succeeded"),
+					},
+				},
+			},
+			ExpectedStdOut: "hello world",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+
+		stdout, stderr := virtualMachineRunScriptOutput(testCase.Input)
+		if stdout != testCase.ExpectedStdOut {
+			t.Fatalf("Expected the StdOut to be %q but got %q", testCase.ExpectedStdOut, stdout)
+		}
+		if stderr != testCase.ExpectedStdErr {
+			t.Fatalf("Expected the StdErr to be %q but got %q", testCase.ExpectedStdErr, stderr)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
@@ -743,6 +744,114 @@ func FlattenVirtualMachineScaleSetAutomaticOSUpgradePolicy(input *compute.Automa
 	}
 }
 
+func VirtualMachineScaleSetRollingUpgradePolicySchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		// Azure defaults the Rolling Upgrade Policy when `upgrade_mode` is `Rolling` and this block is omitted
+		Computed: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"max_batch_instance_percent": {
+					Type:         pluginsdk.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(5, 100),
+				},
+				"max_unhealthy_instance_percent": {
+					Type:         pluginsdk.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(5, 100),
+				},
+				"max_unhealthy_upgraded_instance_percent": {
+					Type:         pluginsdk.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntBetween(0, 100),
+				},
+				"pause_time_between_batches": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: utils.ISO8601Duration,
+				},
+			},
+		},
+	}
+}
+
+// virtualMachineScaleSetRollingUpgradePolicyCustomizeDiff ensures a `rolling_upgrade_policy` is only
+// specified alongside the `Rolling` upgrade mode, both when creating and updating a Scale Set
+func virtualMachineScaleSetRollingUpgradePolicyCustomizeDiff(_ context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("upgrade_mode") {
+		return nil
+	}
+
+	// since this block is Computed, the configuration is checked rather than the value in the state
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	policy := config.GetAttr("rolling_upgrade_policy")
+	if policy.IsNull() || !policy.IsKnown() || policy.LengthInt() == 0 {
+		return nil
+	}
+
+	upgradeMode := compute.UpgradeMode(d.Get("upgrade_mode").(string))
+	if upgradeMode != compute.UpgradeModeRolling {
+		return fmt.Errorf("a `rolling_upgrade_policy` block cannot be specified when `upgrade_mode` is not set to `Rolling`")
+	}
+
+	return nil
+}
+
+func ExpandVirtualMachineScaleSetRollingUpgradePolicy(input []interface{}) *compute.RollingUpgradePolicy {
+	if len(input) == 0 {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &compute.RollingUpgradePolicy{
+		MaxBatchInstancePercent:             utils.Int32(int32(raw["max_batch_instance_percent"].(int))),
+		MaxUnhealthyInstancePercent:         utils.Int32(int32(raw["max_unhealthy_instance_percent"].(int))),
+		MaxUnhealthyUpgradedInstancePercent: utils.Int32(int32(raw["max_unhealthy_upgraded_instance_percent"].(int))),
+		PauseTimeBetweenBatches:             utils.String(raw["pause_time_between_batches"].(string)),
+	}
+}
+
+func FlattenVirtualMachineScaleSetRollingUpgradePolicy(input *compute.RollingUpgradePolicy) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	maxBatchInstancePercent := 0
+	if input.MaxBatchInstancePercent != nil {
+		maxBatchInstancePercent = int(*input.MaxBatchInstancePercent)
+	}
+
+	maxUnhealthyInstancePercent := 0
+	if input.MaxUnhealthyInstancePercent != nil {
+		maxUnhealthyInstancePercent = int(*input.MaxUnhealthyInstancePercent)
+	}
+
+	maxUnhealthyUpgradedInstancePercent := 0
+	if input.MaxUnhealthyUpgradedInstancePercent != nil {
+		maxUnhealthyUpgradedInstancePercent = int(*input.MaxUnhealthyUpgradedInstancePercent)
+	}
+
+	pauseTimeBetweenBatches := ""
+	if input.PauseTimeBetweenBatches != nil {
+		pauseTimeBetweenBatches = *input.PauseTimeBetweenBatches
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"max_batch_instance_percent":              maxBatchInstancePercent,
+			"max_unhealthy_instance_percent":          maxUnhealthyInstancePercent,
+			"max_unhealthy_upgraded_instance_percent": maxUnhealthyUpgradedInstancePercent,
+			"pause_time_between_batches":              pauseTimeBetweenBatches,
+		},
+	}
+}
+
 func VirtualMachineScaleSetTerminateNotificationSchema() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeList,
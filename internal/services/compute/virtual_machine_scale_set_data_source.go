@@ -0,0 +1,373 @@
+package compute
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualMachineScaleSetDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineScaleSetDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"location": commonschema.LocationComputed(),
+
+			"admin_username": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"computer_name_prefix": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"data_disk": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"caching": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"create_option": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"disk_encryption_set_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"disk_size_gb": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"lun": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"storage_account_type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"write_accelerator_enabled": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"instances": {
+				Type:     pluginsdk.TypeInt,
+				Computed: true,
+			},
+
+			"network_interface": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"dns_servers": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"enable_ip_forwarding": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"ip_configuration": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"load_balancer_backend_address_pool_ids": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem: &pluginsdk.Schema{
+											Type: pluginsdk.TypeString,
+										},
+									},
+
+									"load_balancer_inbound_nat_rules_ids": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem: &pluginsdk.Schema{
+											Type: pluginsdk.TypeString,
+										},
+									},
+
+									"primary": {
+										Type:     pluginsdk.TypeBool,
+										Computed: true,
+									},
+
+									"subnet_id": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"version": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+
+						"network_security_group_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"primary": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"os_disk": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"caching": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"diff_disk_settings": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"option": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+
+						"disk_encryption_set_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"disk_size_gb": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"storage_account_type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"write_accelerator_enabled": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"os_type": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"overprovision": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"single_placement_group": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"sku": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"source_image_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"source_image_reference": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"publisher": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"offer": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"sku": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"version": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"unique_id": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"upgrade_mode": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"tags": tags.SchemaDataSource(),
+		},
+	}
+}
+
+func virtualMachineScaleSetDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewVirtualMachineScaleSetID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("location", location.NormalizeNilable(resp.Location))
+
+	var skuName *string
+	var instances int
+	if resp.Sku != nil {
+		skuName = resp.Sku.Name
+		if resp.Sku.Capacity != nil {
+			instances = int(*resp.Sku.Capacity)
+		}
+	}
+	d.Set("sku", skuName)
+	d.Set("instances", instances)
+
+	if props := resp.VirtualMachineScaleSetProperties; props != nil {
+		d.Set("overprovision", props.Overprovision)
+		d.Set("single_placement_group", props.SinglePlacementGroup)
+		d.Set("unique_id", props.UniqueID)
+
+		upgradeMode := ""
+		if policy := props.UpgradePolicy; policy != nil {
+			upgradeMode = string(policy.Mode)
+		}
+		d.Set("upgrade_mode", upgradeMode)
+
+		if profile := props.VirtualMachineProfile; profile != nil {
+			if osProfile := profile.OsProfile; osProfile != nil {
+				d.Set("admin_username", osProfile.AdminUsername)
+				d.Set("computer_name_prefix", osProfile.ComputerNamePrefix)
+			}
+
+			if nwProfile := profile.NetworkProfile; nwProfile != nil {
+				flattenedNics := FlattenVirtualMachineScaleSetNetworkInterface(nwProfile.NetworkInterfaceConfigurations)
+				if err := d.Set("network_interface", flattenedNics); err != nil {
+					return fmt.Errorf("setting `network_interface`: %+v", err)
+				}
+			}
+
+			if storageProfile := profile.StorageProfile; storageProfile != nil {
+				osType := ""
+				if storageProfile.OsDisk != nil {
+					osType = string(storageProfile.OsDisk.OsType)
+				}
+				d.Set("os_type", osType)
+
+				if err := d.Set("os_disk", FlattenVirtualMachineScaleSetOSDisk(storageProfile.OsDisk)); err != nil {
+					return fmt.Errorf("setting `os_disk`: %+v", err)
+				}
+
+				if err := d.Set("data_disk", FlattenVirtualMachineScaleSetDataDisk(storageProfile.DataDisks)); err != nil {
+					return fmt.Errorf("setting `data_disk`: %+v", err)
+				}
+
+				var storageImageId string
+				if storageProfile.ImageReference != nil && storageProfile.ImageReference.ID != nil {
+					storageImageId = *storageProfile.ImageReference.ID
+				}
+				d.Set("source_image_id", storageImageId)
+
+				if err := d.Set("source_image_reference", flattenSourceImageReference(storageProfile.ImageReference)); err != nil {
+					return fmt.Errorf("setting `source_image_reference`: %+v", err)
+				}
+			}
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
@@ -0,0 +1,43 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualMachineScaleSetDataSource struct{}
+
+func TestAccVirtualMachineScaleSetDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_scale_set", "test")
+	r := VirtualMachineScaleSetDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("sku").HasValue("Standard_F2"),
+				check.That(data.ResourceName).Key("instances").HasValue("1"),
+				check.That(data.ResourceName).Key("os_type").HasValue("Linux"),
+				check.That(data.ResourceName).Key("admin_username").HasValue("adminuser"),
+				check.That(data.ResourceName).Key("network_interface.#").HasValue("1"),
+				check.That(data.ResourceName).Key("network_interface.0.ip_configuration.#").HasValue("1"),
+				check.That(data.ResourceName).Key("os_disk.0.storage_account_type").HasValue("Standard_LRS"),
+				check.That(data.ResourceName).Key("unique_id").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualMachineScaleSetDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine_scale_set" "test" {
+  name                = azurestack_linux_virtual_machine_scale_set.test.name
+  resource_group_name = azurestack_linux_virtual_machine_scale_set.test.resource_group_name
+}
+`, LinuxVirtualMachineScaleSetResource{}.authSSHKey(data))
+}
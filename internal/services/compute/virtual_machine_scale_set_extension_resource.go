@@ -38,6 +38,8 @@ func virtualMachineScaleSetExtension() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineExtensionImageCustomizeDiff(virtualMachineScaleSetExtensionLocation)),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
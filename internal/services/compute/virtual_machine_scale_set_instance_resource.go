@@ -0,0 +1,309 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualMachineScaleSetInstance() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: virtualMachineScaleSetInstanceCreate,
+		Read:   virtualMachineScaleSetInstanceRead,
+		Update: virtualMachineScaleSetInstanceUpdate,
+		Delete: virtualMachineScaleSetInstanceDelete,
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.VirtualMachineScaleSetVirtualMachineID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"virtual_machine_scale_set_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.VirtualMachineScaleSetID,
+			},
+
+			"instance_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"protect_from_scale_in": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"protect_from_scale_set_actions": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"redeploy_triggers": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"reimage_triggers": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"restart_triggers": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"name": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"computer_name": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"latest_model_applied": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func virtualMachineScaleSetInstanceCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	scaleSetId, err := parse.VirtualMachineScaleSetID(d.Get("virtual_machine_scale_set_id").(string))
+	if err != nil {
+		return err
+	}
+	id := parse.NewVirtualMachineScaleSetVirtualMachineID(scaleSetId.SubscriptionId, scaleSetId.ResourceGroup, scaleSetId.Name, d.Get("instance_id").(string))
+
+	existing, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	// the triggers only apply to subsequent changes, since the instance already exists
+	if err := virtualMachineScaleSetInstanceUpdateProtectionPolicy(ctx, client, id, existing, d.Get("protect_from_scale_in").(bool), d.Get("protect_from_scale_set_actions").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(id.ID())
+
+	return virtualMachineScaleSetInstanceRead(d, meta)
+}
+
+func virtualMachineScaleSetInstanceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineScaleSetVirtualMachineID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	d.Set("virtual_machine_scale_set_id", parse.NewVirtualMachineScaleSetID(id.SubscriptionId, id.ResourceGroup, id.VirtualMachineScaleSetName).ID())
+	d.Set("instance_id", id.VirtualMachineName)
+	d.Set("name", resp.Name)
+
+	protectFromScaleIn := false
+	protectFromScaleSetActions := false
+	computerName := ""
+	latestModelApplied := false
+	if props := resp.VirtualMachineScaleSetVMProperties; props != nil {
+		if policy := props.ProtectionPolicy; policy != nil {
+			if policy.ProtectFromScaleIn != nil {
+				protectFromScaleIn = *policy.ProtectFromScaleIn
+			}
+			if policy.ProtectFromScaleSetActions != nil {
+				protectFromScaleSetActions = *policy.ProtectFromScaleSetActions
+			}
+		}
+
+		if props.OsProfile != nil && props.OsProfile.ComputerName != nil {
+			computerName = *props.OsProfile.ComputerName
+		}
+
+		if props.LatestModelApplied != nil {
+			latestModelApplied = *props.LatestModelApplied
+		}
+	}
+	d.Set("protect_from_scale_in", protectFromScaleIn)
+	d.Set("protect_from_scale_set_actions", protectFromScaleSetActions)
+	d.Set("computer_name", computerName)
+	d.Set("latest_model_applied", latestModelApplied)
+
+	return nil
+}
+
+func virtualMachineScaleSetInstanceUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineScaleSetVirtualMachineID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// the actions below are performed in turn, so should any of these fail the new values for the triggers
+	// mustn't be persisted - otherwise the failed action wouldn't be retried during the next apply
+	d.Partial(true)
+
+	if d.HasChanges("protect_from_scale_in", "protect_from_scale_set_actions") {
+		existing, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", *id, err)
+		}
+
+		if err := virtualMachineScaleSetInstanceUpdateProtectionPolicy(ctx, client, *id, existing, d.Get("protect_from_scale_in").(bool), d.Get("protect_from_scale_set_actions").(bool)); err != nil {
+			return err
+		}
+	}
+
+	// a Redeploy moves the instance to a new host, after which it can be reimaged and/or restarted
+	if d.HasChange("redeploy_triggers") {
+		log.Printf("[DEBUG] Redeploying %s..", *id)
+		future, err := client.Redeploy(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName)
+		if err != nil {
+			return fmt.Errorf("redeploying %s: %+v", *id, err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for the redeploy of %s: %+v", *id, err)
+		}
+		log.Printf("[DEBUG] Redeployed %s.", *id)
+	}
+
+	if d.HasChange("reimage_triggers") {
+		log.Printf("[DEBUG] Reimaging %s..", *id)
+		future, err := client.Reimage(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, nil)
+		if err != nil {
+			return fmt.Errorf("reimaging %s: %+v", *id, err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for the reimage of %s: %+v", *id, err)
+		}
+		log.Printf("[DEBUG] Reimaged %s.", *id)
+	}
+
+	if d.HasChange("restart_triggers") {
+		log.Printf("[DEBUG] Restarting %s..", *id)
+		future, err := client.Restart(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName)
+		if err != nil {
+			return fmt.Errorf("restarting %s: %+v", *id, err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("waiting for the restart of %s: %+v", *id, err)
+		}
+		log.Printf("[DEBUG] Restarted %s.", *id)
+	}
+
+	d.Partial(false)
+
+	return virtualMachineScaleSetInstanceRead(d, meta)
+}
+
+func virtualMachineScaleSetInstanceDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.VirtualMachineScaleSetVirtualMachineID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// the instance itself is managed by the Virtual Machine Scale Set, as such only the Protection Policy is removed
+	existing, err := client.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return virtualMachineScaleSetInstanceUpdateProtectionPolicy(ctx, client, *id, existing, false, false)
+}
+
+func virtualMachineScaleSetInstanceUpdateProtectionPolicy(ctx context.Context, client *compute.VirtualMachineScaleSetVMsClient, id parse.VirtualMachineScaleSetVirtualMachineId, existing compute.VirtualMachineScaleSetVM, protectFromScaleIn, protectFromScaleSetActions bool) error {
+	if existing.VirtualMachineScaleSetVMProperties == nil {
+		return fmt.Errorf("retrieving %s: `properties` was nil", id)
+	}
+
+	if policy := existing.VirtualMachineScaleSetVMProperties.ProtectionPolicy; policy != nil {
+		if utils.NormaliseNilableBool(policy.ProtectFromScaleIn) == protectFromScaleIn && utils.NormaliseNilableBool(policy.ProtectFromScaleSetActions) == protectFromScaleSetActions {
+			return nil
+		}
+	} else if !protectFromScaleIn && !protectFromScaleSetActions {
+		return nil
+	}
+
+	existing.VirtualMachineScaleSetVMProperties.ProtectionPolicy = &compute.VirtualMachineScaleSetVMProtectionPolicy{
+		ProtectFromScaleIn:         utils.Bool(protectFromScaleIn),
+		ProtectFromScaleSetActions: utils.Bool(protectFromScaleSetActions),
+	}
+
+	log.Printf("[DEBUG] Updating the Protection Policy for %s..", id)
+	future, err := client.Update(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, existing)
+	if err != nil {
+		return fmt.Errorf("updating the Protection Policy for %s: %+v", id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the Protection Policy for %s to be updated: %+v", id, err)
+	}
+	log.Printf("[DEBUG] Updated the Protection Policy for %s.", id)
+
+	return nil
+}
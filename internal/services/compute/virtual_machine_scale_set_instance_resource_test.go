@@ -0,0 +1,193 @@
+package compute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+type VirtualMachineScaleSetInstanceResource struct{}
+
+func TestAccVirtualMachineScaleSetInstance_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_scale_set_instance", "test")
+	r := VirtualMachineScaleSetInstanceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protect_from_scale_in").HasValue("false"),
+				check.That(data.ResourceName).Key("protect_from_scale_set_actions").HasValue("false"),
+				check.That(data.ResourceName).Key("computer_name").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccVirtualMachineScaleSetInstance_protection(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_scale_set_instance", "test")
+	r := VirtualMachineScaleSetInstanceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.protection(data, true, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protect_from_scale_in").HasValue("true"),
+				check.That(data.ResourceName).Key("protect_from_scale_set_actions").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.protection(data, true, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protect_from_scale_in").HasValue("true"),
+				check.That(data.ResourceName).Key("protect_from_scale_set_actions").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protect_from_scale_in").HasValue("false"),
+				check.That(data.ResourceName).Key("protect_from_scale_set_actions").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccVirtualMachineScaleSetInstance_triggers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_virtual_machine_scale_set_instance", "test")
+	r := VirtualMachineScaleSetInstanceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.triggers(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("redeploy_triggers", "reimage_triggers", "restart_triggers"),
+		{
+			Config: r.triggers(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("reimage_triggers.ticket").HasValue("second"),
+			),
+		},
+		data.ImportStep("redeploy_triggers", "reimage_triggers", "restart_triggers"),
+	})
+}
+
+func (VirtualMachineScaleSetInstanceResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.VirtualMachineScaleSetVirtualMachineID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Compute.VMScaleSetVMsClient.Get(ctx, id.ResourceGroup, id.VirtualMachineScaleSetName, id.VirtualMachineName, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (r VirtualMachineScaleSetInstanceResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_scale_set_instance" "test" {
+  virtual_machine_scale_set_id = azurestack_linux_virtual_machine_scale_set.test.id
+  instance_id                  = "0"
+}
+`, r.template(data))
+}
+
+func (r VirtualMachineScaleSetInstanceResource) protection(data acceptance.TestData, protectFromScaleIn, protectFromScaleSetActions bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_scale_set_instance" "test" {
+  virtual_machine_scale_set_id   = azurestack_linux_virtual_machine_scale_set.test.id
+  instance_id                    = "0"
+  protect_from_scale_in          = %t
+  protect_from_scale_set_actions = %t
+}
+`, r.template(data), protectFromScaleIn, protectFromScaleSetActions)
+}
+
+func (r VirtualMachineScaleSetInstanceResource) triggers(data acceptance.TestData, ticket string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_virtual_machine_scale_set_instance" "test" {
+  virtual_machine_scale_set_id = azurestack_linux_virtual_machine_scale_set.test.id
+  instance_id                  = "0"
+
+  reimage_triggers = {
+    ticket = %q
+  }
+
+  restart_triggers = {
+    ticket = %q
+  }
+}
+`, r.template(data), ticket, ticket)
+}
+
+func (VirtualMachineScaleSetInstanceResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_linux_virtual_machine_scale_set" "test" {
+  name                = "acctestvmss-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  sku                 = "Standard_F2"
+  instances           = 1
+  admin_username      = "adminuser"
+  admin_password      = "P@ssword1234!"
+
+  # the instances are numbered sequentially when they're not overprovisioned
+  overprovision = false
+
+  disable_password_authentication = false
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  os_disk {
+    storage_account_type = "Standard_LRS"
+    caching              = "ReadWrite"
+  }
+
+  network_interface {
+    name    = "example"
+    primary = true
+
+    ip_configuration {
+      name      = "internal"
+      primary   = true
+      subnet_id = azurestack_subnet.test.id
+    }
+  }
+}
+`, LinuxVirtualMachineScaleSetResource{}.template(data), data.RandomInteger)
+}
@@ -0,0 +1,248 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func virtualMachineScaleSetInstancesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineScaleSetInstancesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"power_state": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"deallocated",
+					"deallocating",
+					"running",
+					"starting",
+					"stopped",
+					"stopping",
+				}, false),
+			},
+
+			"provisioning_state": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Creating",
+					"Deleting",
+					"Failed",
+					"Succeeded",
+					"Updating",
+				}, false),
+			},
+
+			"instances": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"instance_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"computer_name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"latest_model_applied": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"power_state": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"provisioning_state": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"private_ip_address": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"private_ip_addresses": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"public_ip_address": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"public_ip_addresses": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"virtual_machine_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"zone": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func virtualMachineScaleSetInstancesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMScaleSetVMsClient
+	networkInterfacesClient := meta.(*clients.Client).Network.InterfacesClient
+	publicIPAddressesClient := meta.(*clients.Client).Network.PublicIPsClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewVirtualMachineScaleSetID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
+
+	ipAddresses, err := retrieveIPAddressesForScaleSet(ctx, networkInterfacesClient, publicIPAddressesClient, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving IP Addresses for %s: %+v", id, err)
+	}
+
+	powerStateFilter := d.Get("power_state").(string)
+	provisioningStateFilter := d.Get("provisioning_state").(string)
+
+	instances := make([]interface{}, 0)
+	iterator, err := client.ListComplete(ctx, id.ResourceGroup, id.Name, "", "", "instanceView")
+	if err != nil {
+		return fmt.Errorf("listing instances for %s: %+v", id, err)
+	}
+	for iterator.NotDone() {
+		vm := iterator.Value()
+
+		var instanceId, computerName, name, provisioningState, powerState, vmId, zone string
+		var latestModelApplied bool
+
+		if vm.InstanceID != nil {
+			instanceId = *vm.InstanceID
+		}
+		if vm.Name != nil {
+			name = *vm.Name
+		}
+		if vm.Zones != nil && len(*vm.Zones) > 0 {
+			zone = (*vm.Zones)[0]
+		}
+
+		if props := vm.VirtualMachineScaleSetVMProperties; props != nil {
+			if props.LatestModelApplied != nil {
+				latestModelApplied = *props.LatestModelApplied
+			}
+			if props.ProvisioningState != nil {
+				provisioningState = *props.ProvisioningState
+			}
+			if props.VMID != nil {
+				vmId = *props.VMID
+			}
+			if props.OsProfile != nil && props.OsProfile.ComputerName != nil {
+				computerName = *props.OsProfile.ComputerName
+			}
+			if instanceView := props.InstanceView; instanceView != nil {
+				powerState = virtualMachinePowerStateFromStatuses(instanceView.Statuses)
+			}
+		}
+
+		if (powerStateFilter == "" || strings.EqualFold(powerState, powerStateFilter)) &&
+			(provisioningStateFilter == "" || strings.EqualFold(provisioningState, provisioningStateFilter)) {
+			var instanceResourceId string
+			privateIPAddresses := make([]string, 0)
+			publicIPAddresses := make([]string, 0)
+			if vm.ID != nil {
+				instanceResourceId = *vm.ID
+				if details, ok := ipAddresses[strings.ToLower(*vm.ID)]; ok {
+					privateIPAddresses = details.privateIPAddresses
+					publicIPAddresses = details.publicIPAddresses
+				}
+			}
+
+			primaryPrivateAddress := ""
+			if len(privateIPAddresses) > 0 {
+				primaryPrivateAddress = privateIPAddresses[0]
+			}
+			primaryPublicAddress := ""
+			if len(publicIPAddresses) > 0 {
+				primaryPublicAddress = publicIPAddresses[0]
+			}
+
+			instances = append(instances, map[string]interface{}{
+				"id":                   instanceResourceId,
+				"instance_id":          instanceId,
+				"name":                 name,
+				"computer_name":        computerName,
+				"latest_model_applied": latestModelApplied,
+				"power_state":          powerState,
+				"provisioning_state":   provisioningState,
+				"private_ip_address":   primaryPrivateAddress,
+				"private_ip_addresses": privateIPAddresses,
+				"public_ip_address":    primaryPublicAddress,
+				"public_ip_addresses":  publicIPAddresses,
+				"virtual_machine_id":   vmId,
+				"zone":                 zone,
+			})
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("listing instances for %s: %+v", id, err)
+		}
+	}
+
+	d.SetId(id.ID())
+
+	if err := d.Set("instances", instances); err != nil {
+		return fmt.Errorf("setting `instances`: %+v", err)
+	}
+
+	return nil
+}
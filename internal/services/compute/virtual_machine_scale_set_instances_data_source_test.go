@@ -0,0 +1,68 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualMachineScaleSetInstancesDataSource struct{}
+
+func TestAccVirtualMachineScaleSetInstancesDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_scale_set_instances", "test")
+	r := VirtualMachineScaleSetInstancesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("instances.#").HasValue("1"),
+				check.That(data.ResourceName).Key("instances.0.instance_id").Exists(),
+				check.That(data.ResourceName).Key("instances.0.computer_name").Exists(),
+				check.That(data.ResourceName).Key("instances.0.latest_model_applied").HasValue("true"),
+				check.That(data.ResourceName).Key("instances.0.power_state").HasValue("running"),
+				check.That(data.ResourceName).Key("instances.0.provisioning_state").HasValue("Succeeded"),
+				check.That(data.ResourceName).Key("instances.0.private_ip_address").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineScaleSetInstancesDataSource_filterByPowerState(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_scale_set_instances", "test")
+	r := VirtualMachineScaleSetInstancesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.filterByPowerState(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("instances.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func (VirtualMachineScaleSetInstancesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine_scale_set_instances" "test" {
+  name                = azurestack_linux_virtual_machine_scale_set.test.name
+  resource_group_name = azurestack_linux_virtual_machine_scale_set.test.resource_group_name
+}
+`, LinuxVirtualMachineScaleSetResource{}.authSSHKey(data))
+}
+
+func (VirtualMachineScaleSetInstancesDataSource) filterByPowerState(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine_scale_set_instances" "test" {
+  name                = azurestack_linux_virtual_machine_scale_set.test.name
+  resource_group_name = azurestack_linux_virtual_machine_scale_set.test.resource_group_name
+  power_state         = "deallocated"
+}
+`, LinuxVirtualMachineScaleSetResource{}.authSSHKey(data))
+}
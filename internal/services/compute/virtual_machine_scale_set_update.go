@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/client"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
@@ -22,6 +24,9 @@ type virtualMachineScaleSetUpdateMetaData struct {
 	// do we need to roll the instances in this scale set?
 	UpdateInstances bool
 
+	// have the extensions for this scale set changed?
+	UpdateExtensions bool
+
 	Client   *client.Client
 	Existing compute.VirtualMachineScaleSet
 	ID       *parse.VirtualMachineScaleSetId
@@ -47,6 +52,14 @@ func (metadata virtualMachineScaleSetUpdateMetaData) performUpdate(ctx context.C
 		userWantsToRollInstances := metadata.CanRollInstancesWhenRequired
 		upgradeMode := metadata.Existing.VirtualMachineScaleSetProperties.UpgradePolicy.Mode
 
+		// the platform rolls out the other changes to the model when using a Rolling Upgrade Policy,
+		// however the Extensions have to be explicitly upgraded - which is governed by the policy itself
+		if upgradeMode == compute.UpgradeModeRolling && metadata.UpdateExtensions {
+			if err := metadata.upgradeExtensionsForRollingUpgradePolicy(ctx); err != nil {
+				return err
+			}
+		}
+
 		if userWantsToRollInstances {
 			// If the updated image version is not "latest" and upgrade mode is automatic then azure will roll the instances automatically.
 			// Calling upgradeInstancesForAutomaticUpgradePolicy() in this case will cause an error.
@@ -102,7 +115,6 @@ func (metadata virtualMachineScaleSetUpdateMetaData) updateVmss(ctx context.Cont
 }
 
 func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForAutomaticUpgradePolicy(ctx context.Context) error {
-	client := metadata.Client.VMScaleSetClient
 	rollingUpgradesClient := metadata.Client.VMScaleSetRollingUpgradesClient
 	id := metadata.ID
 
@@ -113,7 +125,7 @@ func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForAutomati
 	}
 
 	log.Printf("[DEBUG] Waiting for update of instances for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
-	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+	if err = metadata.waitForRollingUpgrade(ctx, future.FutureAPI); err != nil {
 		return fmt.Errorf("waiting for update of instances for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
 	}
 	log.Printf("[DEBUG] Updated instances for %s Virtual Machine Scale Set %q (Resource Group %q).", metadata.OSType, id.Name, id.ResourceGroup)
@@ -121,6 +133,101 @@ func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForAutomati
 	return nil
 }
 
+func (metadata virtualMachineScaleSetUpdateMetaData) upgradeExtensionsForRollingUpgradePolicy(ctx context.Context) error {
+	rollingUpgradesClient := metadata.Client.VMScaleSetRollingUpgradesClient
+	id := metadata.ID
+
+	log.Printf("[DEBUG] Upgrading extensions for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
+	future, err := rollingUpgradesClient.StartExtensionUpgrade(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("upgrading extensions for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+
+	log.Printf("[DEBUG] Waiting for upgrade of extensions for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
+	if err = metadata.waitForRollingUpgrade(ctx, future.FutureAPI); err != nil {
+		return fmt.Errorf("waiting for upgrade of extensions for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+	}
+	log.Printf("[DEBUG] Upgraded extensions for %s Virtual Machine Scale Set %q (Resource Group %q).", metadata.OSType, id.Name, id.ResourceGroup)
+
+	return nil
+}
+
+// waitForRollingUpgrade polls the Rolling Upgrade until it's completed, logging the progress as it goes. When the
+// context is cancelled (for example when Terraform is interrupted) the Rolling Upgrade is cancelled, rather than
+// being left to continue in the background.
+func (metadata virtualMachineScaleSetUpdateMetaData) waitForRollingUpgrade(ctx context.Context, future azure.FutureAPI) error {
+	client := metadata.Client.VMScaleSetRollingUpgradesClient
+	id := metadata.ID
+
+	for {
+		done, err := future.DoneWithContext(ctx, client.Client)
+		if err != nil {
+			if ctx.Err() != nil {
+				return metadata.cancelRollingUpgrade(ctx.Err())
+			}
+			return err
+		}
+		if done {
+			break
+		}
+
+		if latest, err := client.GetLatest(ctx, id.ResourceGroup, id.Name); err != nil {
+			log.Printf("[DEBUG] retrieving the progress of the Rolling Upgrade for %s Virtual Machine Scale Set %q (Resource Group %q): %+v", metadata.OSType, id.Name, id.ResourceGroup, err)
+		} else if props := latest.RollingUpgradeStatusInfoProperties; props != nil {
+			log.Printf("[INFO] Rolling Upgrade for %s Virtual Machine Scale Set %q (Resource Group %q): %s", metadata.OSType, id.Name, id.ResourceGroup, rollingUpgradeProgressSummary(props.Progress))
+		}
+
+		delay, ok := future.GetPollingDelay()
+		if !ok {
+			delay = client.PollingDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			return metadata.cancelRollingUpgrade(ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+
+	latest, err := client.GetLatest(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving the latest Rolling Upgrade: %+v", err)
+	}
+	if props := latest.RollingUpgradeStatusInfoProperties; props != nil && props.RunningStatus != nil {
+		switch props.RunningStatus.Code {
+		case compute.Cancelled, compute.Faulted:
+			message := ""
+			if props.Error != nil && props.Error.Message != nil {
+				message = *props.Error.Message
+			}
+			return fmt.Errorf("the Rolling Upgrade finished with the status %q (%s): %s", string(props.RunningStatus.Code), rollingUpgradeProgressSummary(props.Progress), message)
+		}
+	}
+
+	return nil
+}
+
+func (metadata virtualMachineScaleSetUpdateMetaData) cancelRollingUpgrade(reason error) error {
+	client := metadata.Client.VMScaleSetRollingUpgradesClient
+	id := metadata.ID
+
+	// the original context is done at this point, so a new one is needed to cancel the Rolling Upgrade
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	log.Printf("[DEBUG] Cancelling the Rolling Upgrade for %s Virtual Machine Scale Set %q (Resource Group %q)..", metadata.OSType, id.Name, id.ResourceGroup)
+	future, err := client.Cancel(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("cancelling the Rolling Upgrade after %+v: %+v", reason, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for cancellation of the Rolling Upgrade after %+v: %+v", reason, err)
+	}
+	log.Printf("[DEBUG] Cancelled the Rolling Upgrade for %s Virtual Machine Scale Set %q (Resource Group %q).", metadata.OSType, id.Name, id.ResourceGroup)
+
+	return fmt.Errorf("the Rolling Upgrade was cancelled: %+v", reason)
+}
+
 func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForManualUpgradePolicy(ctx context.Context) error {
 	client := metadata.Client.VMScaleSetClient
 	id := metadata.ID
@@ -198,3 +305,18 @@ func isUsingLatestImage(update compute.VirtualMachineScaleSetUpdate) bool {
 	}
 	return false
 }
+
+func rollingUpgradeProgressSummary(input *compute.RollingUpgradeProgressInfo) string {
+	if input == nil {
+		return "no progress reported"
+	}
+
+	count := func(v *int32) int32 {
+		if v == nil {
+			return 0
+		}
+		return *v
+	}
+
+	return fmt.Sprintf("%d successful, %d failed, %d in progress, %d pending", count(input.SuccessfulInstanceCount), count(input.FailedInstanceCount), count(input.InProgressInstanceCount), count(input.PendingInstanceCount))
+}
@@ -0,0 +1,43 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestRollingUpgradeProgressSummary(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    *compute.RollingUpgradeProgressInfo
+		Expected string
+	}{
+		{
+			Name:     "None",
+			Expected: "no progress reported",
+		},
+		{
+			Name:     "Empty",
+			Input:    &compute.RollingUpgradeProgressInfo{},
+			Expected: "0 successful, 0 failed, 0 in progress, 0 pending",
+		},
+		{
+			Name: "In Progress",
+			Input: &compute.RollingUpgradeProgressInfo{
+				SuccessfulInstanceCount: utils.Int32(2),
+				FailedInstanceCount:     utils.Int32(1),
+				InProgressInstanceCount: utils.Int32(3),
+				PendingInstanceCount:    utils.Int32(4),
+			},
+			Expected: "2 successful, 1 failed, 3 in progress, 4 pending",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+		if actual := rollingUpgradeProgressSummary(testCase.Input); actual != testCase.Expected {
+			t.Fatalf("Expected %q but got %q", testCase.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/features"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+// cachedVirtualMachineSizes holds the Virtual Machine Sizes available in each location, keyed by the
+// normalized location. A location is only cached once it's been successfully retrieved.
+var (
+	cachedVirtualMachineSizes     = map[string][]compute.VirtualMachineSize{}
+	cachedVirtualMachineSizesLock = sync.Mutex{}
+)
+
+// virtualMachineSizeCustomizeDiff returns a CustomizeDiffFunc which validates the Virtual Machine Size specified
+// in `sizeField` (and, when specified, the number of items in `dataDiskField`) against the Sizes available in
+// the location of the resource - such that an invalid Size is surfaced during the plan rather than partway
+// through a (potentially long) create.
+//
+// NOTE: this is best-effort - when Enhanced Validation is disabled, or the available Sizes can't be retrieved,
+// no validation is performed and the API will surface any error as before.
+func virtualMachineSizeCustomizeDiff(sizeField, dataDiskField string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		if !features.EnhancedValidationEnabled() {
+			return nil
+		}
+
+		// existing resources are only validated when they're changing, since a Size which has since been
+		// retired shouldn't block unrelated changes
+		changed := d.HasChange(sizeField)
+		if dataDiskField != "" && d.HasChange(dataDiskField) {
+			changed = true
+		}
+		if !changed || !d.NewValueKnown(sizeField) || !d.NewValueKnown("location") {
+			return nil
+		}
+
+		size := d.Get(sizeField).(string)
+		loc := location.Normalize(d.Get("location").(string))
+		if size == "" || loc == "" {
+			return nil
+		}
+
+		client := meta.(*clients.Client).Compute.VMSizesClient
+		sizes := availableVirtualMachineSizes(ctx, client, loc)
+		if sizes == nil {
+			return nil
+		}
+
+		dataDiskCount := 0
+		if dataDiskField != "" {
+			dataDiskCount = len(d.Get(dataDiskField).([]interface{}))
+		}
+
+		return validateVirtualMachineSize(*sizes, sizeField, size, loc, dataDiskCount)
+	}
+}
+
+// availableVirtualMachineSizes returns the (cached) Virtual Machine Sizes available in the specified location,
+// or nil if these couldn't be retrieved.
+func availableVirtualMachineSizes(ctx context.Context, client *compute.VirtualMachineSizesClient, loc string) *[]compute.VirtualMachineSize {
+	cachedVirtualMachineSizesLock.Lock()
+	defer cachedVirtualMachineSizesLock.Unlock()
+
+	if sizes, ok := cachedVirtualMachineSizes[loc]; ok {
+		return &sizes
+	}
+
+	resp, err := client.List(ctx, loc)
+	if err != nil {
+		log.Printf("[DEBUG] error retrieving Virtual Machine Sizes in location %q: %+v. Enhanced validation will be unavailable", loc, err)
+		return nil
+	}
+	if resp.Value == nil || len(*resp.Value) == 0 {
+		log.Printf("[DEBUG] no Virtual Machine Sizes were returned for location %q. Enhanced validation will be unavailable", loc)
+		return nil
+	}
+
+	cachedVirtualMachineSizes[loc] = *resp.Value
+	sizes := *resp.Value
+	return &sizes
+}
+
+// validateVirtualMachineSize confirms that the specified Size is available and supports the specified number of Data Disks
+func validateVirtualMachineSize(available []compute.VirtualMachineSize, field, size, loc string, dataDiskCount int) error {
+	names := make([]string, 0)
+	for _, v := range available {
+		if v.Name == nil {
+			continue
+		}
+
+		if !strings.EqualFold(*v.Name, size) {
+			names = append(names, *v.Name)
+			continue
+		}
+
+		if v.MaxDataDiskCount != nil && dataDiskCount > int(*v.MaxDataDiskCount) {
+			return fmt.Errorf("the %s %q supports a maximum of %d Data Disks but %d were specified", field, size, *v.MaxDataDiskCount, dataDiskCount)
+		}
+
+		return nil
+	}
+
+	if suggestion := closestVirtualMachineSize(size, names); suggestion != "" {
+		return fmt.Errorf("the %s %q is not available in location %q - did you mean %q?", field, size, loc, suggestion)
+	}
+
+	return fmt.Errorf("the %s %q is not available in location %q - the available sizes can be found using the `azurestack_virtual_machine_sizes` Data Source", field, size, loc)
+}
+
+// closestVirtualMachineSize returns the name from `names` which is most similar to `size`, or an empty string
+// when none are similar enough to be a useful suggestion
+func closestVirtualMachineSize(size string, names []string) string {
+	suggestion := ""
+	// since most sizes share a common prefix, anything more than a couple of edits away is more likely
+	// to be a different size than a typo
+	bestDistance := 3
+	for _, name := range names {
+		distance := levenshteinDistance(strings.ToLower(size), strings.ToLower(name))
+		if distance < bestDistance {
+			bestDistance = distance
+			suggestion = name
+		}
+	}
+
+	return suggestion
+}
+
+func levenshteinDistance(first, second string) int {
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(second)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
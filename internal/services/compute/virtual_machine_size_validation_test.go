@@ -0,0 +1,130 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func TestValidateVirtualMachineSize(t *testing.T) {
+	available := []compute.VirtualMachineSize{
+		{
+			Name:             utils.String("Standard_A1"),
+			MaxDataDiskCount: utils.Int32(2),
+		},
+		{
+			Name:             utils.String("Standard_DS2_v2"),
+			MaxDataDiskCount: utils.Int32(8),
+		},
+		{
+			Name: utils.String("Standard_F2"),
+		},
+	}
+
+	testCases := []struct {
+		Name          string
+		Size          string
+		DataDiskCount int
+		Expected      string
+	}{
+		{
+			Name: "Available",
+			Size: "Standard_DS2_v2",
+		},
+		{
+			Name: "Available Different Casing",
+			Size: "standard_ds2_v2",
+		},
+		{
+			Name:          "Within Data Disk Limit",
+			Size:          "Standard_A1",
+			DataDiskCount: 2,
+		},
+		{
+			Name:          "Exceeds Data Disk Limit",
+			Size:          "Standard_A1",
+			DataDiskCount: 3,
+			Expected:      `the size "Standard_A1" supports a maximum of 2 Data Disks but 3 were specified`,
+		},
+		{
+			Name:          "No Data Disk Limit Returned",
+			Size:          "Standard_F2",
+			DataDiskCount: 64,
+		},
+		{
+			Name:     "Typo",
+			Size:     "Standard_DS2_V3",
+			Expected: `the size "Standard_DS2_V3" is not available in location "westus" - did you mean "Standard_DS2_v2"?`,
+		},
+		{
+			Name:     "Unavailable",
+			Size:     "Standard_M128ms",
+			Expected: `the size "Standard_M128ms" is not available in location "westus" - the available sizes can be found using the ` + "`azurestack_virtual_machine_sizes`" + ` Data Source`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Name)
+		err := validateVirtualMachineSize(available, "size", testCase.Size, "westus", testCase.DataDiskCount)
+		if testCase.Expected == "" {
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+			continue
+		}
+
+		if err == nil {
+			t.Fatalf("Expected the error %q but didn't get one", testCase.Expected)
+		}
+		if err.Error() != testCase.Expected {
+			t.Fatalf("Expected the error %q but got %q", testCase.Expected, err.Error())
+		}
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	testCases := []struct {
+		First    string
+		Second   string
+		Expected int
+	}{
+		{
+			First:    "",
+			Second:   "",
+			Expected: 0,
+		},
+		{
+			First:    "standard_a1",
+			Second:   "",
+			Expected: 11,
+		},
+		{
+			First:    "standard_a1",
+			Second:   "standard_a1",
+			Expected: 0,
+		},
+		{
+			First:    "standard_a1",
+			Second:   "standard_a2",
+			Expected: 1,
+		},
+		{
+			First:    "standard_d2_v2",
+			Second:   "standard_ds2_v2",
+			Expected: 1,
+		},
+		{
+			First:    "kitten",
+			Second:   "sitting",
+			Expected: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q / %q..", testCase.First, testCase.Second)
+		if actual := levenshteinDistance(testCase.First, testCase.Second); actual != testCase.Expected {
+			t.Fatalf("Expected %d but got %d", testCase.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func virtualMachineSizesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualMachineSizesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"location": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"location", "availability_set_id", "virtual_machine_id"},
+				StateFunc:        location.StateFunc,
+				DiffSuppressFunc: location.DiffSuppressFunc,
+			},
+
+			// lists the sizes which an existing Virtual Machine can be resized to
+			"availability_set_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"location", "availability_set_id", "virtual_machine_id"},
+				ValidateFunc: validate.AvailabilitySetID,
+			},
+
+			"virtual_machine_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"location", "availability_set_id", "virtual_machine_id"},
+				ValidateFunc: validate.VirtualMachineID,
+			},
+
+			"names": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"sizes": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"number_of_cores": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"memory_in_mb": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"max_data_disk_count": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"os_disk_size_in_mb": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"resource_disk_size_in_mb": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func virtualMachineSizesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Compute.VMSizesClient
+	availabilitySetsClient := meta.(*clients.Client).Compute.AvailabilitySetsClient
+	vmClient := meta.(*clients.Client).Compute.VMClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	var resp compute.VirtualMachineSizeListResult
+
+	if v := d.Get("availability_set_id").(string); v != "" {
+		id, err := parse.AvailabilitySetID(v)
+		if err != nil {
+			return err
+		}
+
+		resp, err = availabilitySetsClient.ListAvailableSizes(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return fmt.Errorf("listing available sizes for %s: %+v", *id, err)
+		}
+	} else if v := d.Get("virtual_machine_id").(string); v != "" {
+		id, err := parse.VirtualMachineID(v)
+		if err != nil {
+			return err
+		}
+
+		resp, err = vmClient.ListAvailableSizes(ctx, id.ResourceGroup, id.Name)
+		if err != nil {
+			return fmt.Errorf("listing available sizes for %s: %+v", *id, err)
+		}
+	} else {
+		loc := location.Normalize(d.Get("location").(string))
+
+		var err error
+		resp, err = client.List(ctx, loc)
+		if err != nil {
+			return fmt.Errorf("listing Virtual Machine Sizes in location %q: %+v", loc, err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	names, sizes := flattenVirtualMachineSizes(resp.Value)
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("setting `names`: %+v", err)
+	}
+	if err := d.Set("sizes", sizes); err != nil {
+		return fmt.Errorf("setting `sizes`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenVirtualMachineSizes(input *[]compute.VirtualMachineSize) ([]string, []interface{}) {
+	names := make([]string, 0)
+	sizes := make([]interface{}, 0)
+	if input == nil {
+		return names, sizes
+	}
+
+	// the API doesn't guarantee an order, so these are sorted to keep the data source stable
+	values := *input
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i].Name == nil || values[j].Name == nil {
+			return values[j].Name != nil
+		}
+		return strings.ToLower(*values[i].Name) < strings.ToLower(*values[j].Name)
+	})
+
+	for _, v := range values {
+		if v.Name == nil {
+			continue
+		}
+
+		numberOfCores := 0
+		if v.NumberOfCores != nil {
+			numberOfCores = int(*v.NumberOfCores)
+		}
+		memoryInMB := 0
+		if v.MemoryInMB != nil {
+			memoryInMB = int(*v.MemoryInMB)
+		}
+		maxDataDiskCount := 0
+		if v.MaxDataDiskCount != nil {
+			maxDataDiskCount = int(*v.MaxDataDiskCount)
+		}
+		osDiskSizeInMB := 0
+		if v.OsDiskSizeInMB != nil {
+			osDiskSizeInMB = int(*v.OsDiskSizeInMB)
+		}
+		resourceDiskSizeInMB := 0
+		if v.ResourceDiskSizeInMB != nil {
+			resourceDiskSizeInMB = int(*v.ResourceDiskSizeInMB)
+		}
+
+		names = append(names, *v.Name)
+		sizes = append(sizes, map[string]interface{}{
+			"name":                     *v.Name,
+			"number_of_cores":          numberOfCores,
+			"memory_in_mb":             memoryInMB,
+			"max_data_disk_count":      maxDataDiskCount,
+			"os_disk_size_in_mb":       osDiskSizeInMB,
+			"resource_disk_size_in_mb": resourceDiskSizeInMB,
+		})
+	}
+
+	return names, sizes
+}
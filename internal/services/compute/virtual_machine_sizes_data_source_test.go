@@ -0,0 +1,89 @@
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualMachineSizesDataSource struct{}
+
+func TestAccVirtualMachineSizesDataSource_location(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_sizes", "test")
+	r := VirtualMachineSizesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.location(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("names.#").Exists(),
+				check.That(data.ResourceName).Key("sizes.0.name").Exists(),
+				check.That(data.ResourceName).Key("sizes.0.number_of_cores").Exists(),
+				check.That(data.ResourceName).Key("sizes.0.memory_in_mb").Exists(),
+				check.That(data.ResourceName).Key("sizes.0.max_data_disk_count").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineSizesDataSource_availabilitySet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_sizes", "test")
+	r := VirtualMachineSizesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.availabilitySet(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("sizes.0.name").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccVirtualMachineSizesDataSource_virtualMachine(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_machine_sizes", "test")
+	r := VirtualMachineSizesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.virtualMachine(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("sizes.0.name").Exists(),
+			),
+		},
+	})
+}
+
+func (VirtualMachineSizesDataSource) location(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+data "azurestack_virtual_machine_sizes" "test" {
+  location = "%s"
+}
+`, data.Locations.Primary)
+}
+
+func (VirtualMachineSizesDataSource) availabilitySet(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine_sizes" "test" {
+  availability_set_id = azurestack_availability_set.test.id
+}
+`, AvailabilitySetResource{}.basic(data))
+}
+
+func (VirtualMachineSizesDataSource) virtualMachine(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_machine_sizes" "test" {
+  virtual_machine_id = azurestack_linux_virtual_machine.test.id
+}
+`, LinuxVirtualMachineResource{}.authSSH(data))
+}
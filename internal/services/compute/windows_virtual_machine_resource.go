@@ -46,6 +46,11 @@ func windowsVirtualMachine() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(45 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			virtualMachineSizeCustomizeDiff("size", ""),
+			computeQuotaCustomizeDiff("size", ""),
+		),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -1090,12 +1095,9 @@ func resourceWindowsVirtualMachineDelete(d *pluginsdk.ResourceData, meta interfa
 			// https://docs.microsoft.com/en-us/azure/virtual-machines/states-lifecycle
 			log.Printf("[DEBUG] Powering Off Windows Virtual Machine %q (Resource Group %q)..", id.Name, id.ResourceGroup)
 			var skipShutdown *bool = nil
-			powerOffFuture, err := client.PowerOff(ctx, id.ResourceGroup, id.Name, skipShutdown)
-			if err != nil {
-				return fmt.Errorf("powering off Windows Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
-			}
-			if err := powerOffFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
-				return fmt.Errorf("waiting for power off of Windows Virtual Machine %q (Resource Group %q): %+v", id.Name, id.ResourceGroup, err)
+			description := fmt.Sprintf("Windows Virtual Machine %q (Resource Group %q)", id.Name, id.ResourceGroup)
+			if err := virtualMachinePowerOff(ctx, client, *id, skipShutdown, description); err != nil {
+				return err
 			}
 			log.Printf("[DEBUG] Powered Off Windows Virtual Machine %q (Resource Group %q).", id.Name, id.ResourceGroup)
 		}
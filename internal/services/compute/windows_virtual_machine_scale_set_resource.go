@@ -40,6 +40,12 @@ func resourceWindowsVirtualMachineScaleSet() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			virtualMachineSizeCustomizeDiff("sku", "data_disk"),
+			computeQuotaCustomizeDiff("sku", "instances"),
+			virtualMachineScaleSetRollingUpgradePolicyCustomizeDiff,
+		),
+
 		// TODO: exposing requireGuestProvisionSignal once it's available
 		// https://github.com/Azure/azure-rest-api-specs/pull/7246
 
@@ -187,6 +193,8 @@ func resourceWindowsVirtualMachineScaleSet() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"rolling_upgrade_policy": VirtualMachineScaleSetRollingUpgradePolicySchema(),
+
 			"secret": windowsSecretSchema(),
 
 			"single_placement_group": {
@@ -312,6 +320,9 @@ func resourceWindowsVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta
 		return fmt.Errorf("an `automatic_os_upgrade_policy` block cannot be specified when `upgrade_mode` is not set to `Automatic`")
 	}
 
+	rollingUpgradePolicyRaw := d.Get("rolling_upgrade_policy").([]interface{})
+	rollingUpgradePolicy := ExpandVirtualMachineScaleSetRollingUpgradePolicy(rollingUpgradePolicyRaw)
+
 	winRmListenersRaw := d.Get("winrm_listener").(*pluginsdk.Set).List()
 	winRmListeners := expandWinRMListener(winRmListenersRaw)
 
@@ -341,6 +352,7 @@ func resourceWindowsVirtualMachineScaleSetCreate(d *pluginsdk.ResourceData, meta
 	upgradePolicy := compute.UpgradePolicy{
 		Mode:                     upgradeMode,
 		AutomaticOSUpgradePolicy: automaticOSUpgradePolicy,
+		RollingUpgradePolicy:     rollingUpgradePolicy,
 	}
 
 	virtualMachineProfile := compute.VirtualMachineScaleSetVMProfile{
@@ -468,6 +480,7 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 	}
 
 	updateInstances := false
+	updateExtensions := false
 
 	// retrieve
 	// Upgrading to the 2021-07-01 exposed a new expand parameter in the GET method
@@ -508,7 +521,7 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 			automaticOSUpgradeIsEnabled = *policy.AutomaticOSUpgradePolicy.EnableAutomaticOSUpgrade
 		}
 	}
-	if d.HasChange("automatic_os_upgrade_policy") {
+	if d.HasChange("automatic_os_upgrade_policy") || d.HasChange("rolling_upgrade_policy") {
 		upgradePolicy := compute.UpgradePolicy{}
 		if existing.VirtualMachineScaleSetProperties.UpgradePolicy == nil {
 			upgradePolicy = compute.UpgradePolicy{
@@ -527,6 +540,12 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 			// we can guarantee this always has a value since it'll have been expanded and thus is safe to de-ref
 			automaticOSUpgradeIsEnabled = *upgradePolicy.AutomaticOSUpgradePolicy.EnableAutomaticOSUpgrade
 		}
+
+		if d.HasChange("rolling_upgrade_policy") {
+			rollingRaw := d.Get("rolling_upgrade_policy").([]interface{})
+			upgradePolicy.RollingUpgradePolicy = ExpandVirtualMachineScaleSetRollingUpgradePolicy(rollingRaw)
+		}
+
 		updateProps.UpgradePolicy = &upgradePolicy
 	}
 
@@ -716,6 +735,7 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 
 	if d.HasChanges("extension") {
 		updateInstances = true
+		updateExtensions = true
 
 		extensionProfile, _, err := expandVirtualMachineScaleSetExtensions(d.Get("extension").(*pluginsdk.Set).List())
 		if err != nil {
@@ -733,6 +753,7 @@ func resourceWindowsVirtualMachineScaleSetUpdate(d *pluginsdk.ResourceData, meta
 	metaData := virtualMachineScaleSetUpdateMetaData{
 		AutomaticOSUpgradeIsEnabled:  automaticOSUpgradeIsEnabled,
 		CanRollInstancesWhenRequired: meta.(*clients.Client).Features.VirtualMachineScaleSet.RollInstancesWhenRequired,
+		UpdateExtensions:             updateExtensions,
 		UpdateInstances:              updateInstances,
 		Client:                       meta.(*clients.Client).Compute,
 		Existing:                     existing,
@@ -815,6 +836,11 @@ func resourceWindowsVirtualMachineScaleSetRead(d *pluginsdk.ResourceData, meta i
 		if err := d.Set("automatic_os_upgrade_policy", flattenedAutomatic); err != nil {
 			return fmt.Errorf("setting `automatic_os_upgrade_policy`: %+v", err)
 		}
+
+		flattenedRolling := FlattenVirtualMachineScaleSetRollingUpgradePolicy(policy.RollingUpgradePolicy)
+		if err := d.Set("rolling_upgrade_policy", flattenedRolling); err != nil {
+			return fmt.Errorf("setting `rolling_upgrade_policy`: %+v", err)
+		}
 	}
 
 	rule := string(compute.Default)
@@ -119,3 +119,27 @@ func nestedItemResourceImporter(d *schema.ResourceData, meta interface{}) ([]*sc
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// keyVaultChildResourceImporter returns an importer for items within a Key Vault which aren't Nested Items (such as
+// Certificate Issuers), using baseUrlFromId to determine the URI of the Key Vault from the ID being imported
+func keyVaultChildResourceImporter(baseUrlFromId func(input string) (*string, error)) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		keyVaultsClient := meta.(*clients.Client).KeyVault
+		resourcesClient := meta.(*clients.Client).Resource
+		ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+		defer cancel()
+
+		keyVaultBaseUrl, err := baseUrlFromId(d.Id())
+		if err != nil {
+			return []*schema.ResourceData{d}, fmt.Errorf("parsing ID %q for Key Vault Child import: %v", d.Id(), err)
+		}
+
+		keyVaultId, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, resourcesClient, *keyVaultBaseUrl)
+		if err != nil {
+			return []*schema.ResourceData{d}, fmt.Errorf("retrieving the Resource ID the Key Vault at URL %q: %s", *keyVaultBaseUrl, err)
+		}
+		d.Set("key_vault_id", keyVaultId)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,267 @@
+package keyvault
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func keyVaultCertificateContacts() *schema.Resource {
+	return &schema.Resource{
+		Create: keyVaultCertificateContactsCreateUpdate,
+		Read:   keyVaultCertificateContactsRead,
+		Update: keyVaultCertificateContactsCreateUpdate,
+		Delete: keyVaultCertificateContactsDelete,
+		Importer: &schema.ResourceImporter{
+			State: keyVaultChildResourceImporter(func(input string) (*string, error) {
+				id, err := parse.CertificateContactsID(input)
+				if err != nil {
+					return nil, err
+				}
+				return &id.KeyVaultBaseUrl, nil
+			}),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"key_vault_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.VaultID,
+			},
+
+			"contact": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"phone": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func keyVaultCertificateContactsCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	keyVaultId, err := parse.VaultID(d.Get("key_vault_id").(string))
+	if err != nil {
+		return err
+	}
+
+	keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("looking up Certificate Contacts vault url from id %q: %+v", *keyVaultId, err)
+	}
+
+	id, err := parse.NewCertificateContactsID(*keyVaultBaseUri)
+	if err != nil {
+		return err
+	}
+
+	if d.IsNewResource() {
+		existing, err := client.GetCertificateContacts(ctx, *keyVaultBaseUri)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing Certificate Contacts (Key Vault %q): %s", *keyVaultBaseUri, err)
+			}
+		}
+
+		if existing.ContactList != nil && len(*existing.ContactList) > 0 {
+			return tf.ImportAsExistsError("azurestack_key_vault_certificate_contacts", id.ID())
+		}
+	}
+
+	parameters := keyvault.Contacts{
+		ContactList: expandKeyVaultCertificateContacts(d.Get("contact").([]interface{})),
+	}
+
+	if _, err := client.SetCertificateContacts(ctx, *keyVaultBaseUri, parameters); err != nil {
+		return fmt.Errorf("setting Certificate Contacts (Key Vault %q): %+v", *keyVaultBaseUri, err)
+	}
+
+	d.SetId(id.ID())
+
+	return keyVaultCertificateContactsRead(d, meta)
+}
+
+func keyVaultCertificateContactsRead(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	resourcesClient := meta.(*clients.Client).Resource
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.CertificateContactsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultIdRaw, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, resourcesClient, id.KeyVaultBaseUrl)
+	if err != nil {
+		return fmt.Errorf("retrieving the Resource ID the Key Vault at URL %q: %s", id.KeyVaultBaseUrl, err)
+	}
+	if keyVaultIdRaw == nil {
+		log.Printf("[DEBUG] Unable to determine the Resource ID for the Key Vault at URL %q - removing from state!", id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+	keyVaultId, err := parse.VaultID(*keyVaultIdRaw)
+	if err != nil {
+		return err
+	}
+
+	ok, err := keyVaultsClient.Exists(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("checking if key vault %q for Certificate Contacts in Vault at url %q exists: %v", *keyVaultId, id.KeyVaultBaseUrl, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] Certificate Contacts Key Vault %q was not found in Key Vault at URI %q - removing from state", *keyVaultId, id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+
+	resp, err := client.GetCertificateContacts(ctx, id.KeyVaultBaseUrl)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Certificate Contacts were not found in Key Vault at URI %q - removing from state", id.KeyVaultBaseUrl)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("reading Certificate Contacts (Key Vault %q): %+v", id.KeyVaultBaseUrl, err)
+	}
+
+	d.Set("key_vault_id", keyVaultId.ID())
+
+	if err := d.Set("contact", flattenKeyVaultCertificateContacts(resp.ContactList)); err != nil {
+		return fmt.Errorf("setting `contact`: %+v", err)
+	}
+
+	return nil
+}
+
+func keyVaultCertificateContactsDelete(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	resourcesClient := meta.(*clients.Client).Resource
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.CertificateContactsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultIdRaw, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, resourcesClient, id.KeyVaultBaseUrl)
+	if err != nil {
+		return fmt.Errorf("retrieving the Resource ID the Key Vault at URL %q: %s", id.KeyVaultBaseUrl, err)
+	}
+	if keyVaultIdRaw == nil {
+		return fmt.Errorf("Unable to determine the Resource ID for the Key Vault at URL %q", id.KeyVaultBaseUrl)
+	}
+	keyVaultId, err := parse.VaultID(*keyVaultIdRaw)
+	if err != nil {
+		return err
+	}
+
+	ok, err := keyVaultsClient.Exists(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("checking if key vault %q for Certificate Contacts in Vault at url %q exists: %v", *keyVaultId, id.KeyVaultBaseUrl, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] Certificate Contacts Key Vault %q was not found in Key Vault at URI %q - removing from state", *keyVaultId, id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+
+	resp, err := client.DeleteCertificateContacts(ctx, id.KeyVaultBaseUrl)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil
+		}
+		return fmt.Errorf("deleting Certificate Contacts (Key Vault %q): %+v", id.KeyVaultBaseUrl, err)
+	}
+
+	return nil
+}
+
+func expandKeyVaultCertificateContacts(input []interface{}) *[]keyvault.Contact {
+	results := make([]keyvault.Contact, 0)
+
+	for _, v := range input {
+		if v == nil {
+			continue
+		}
+		raw := v.(map[string]interface{})
+
+		contact := keyvault.Contact{
+			EmailAddress: utils.String(raw["email"].(string)),
+		}
+		if name := raw["name"].(string); name != "" {
+			contact.Name = utils.String(name)
+		}
+		if phone := raw["phone"].(string); phone != "" {
+			contact.Phone = utils.String(phone)
+		}
+
+		results = append(results, contact)
+	}
+
+	return &results
+}
+
+func flattenKeyVaultCertificateContacts(input *[]keyvault.Contact) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, contact := range *input {
+		results = append(results, map[string]interface{}{
+			"email": utils.NormalizeNilableString(contact.EmailAddress),
+			"name":  utils.NormalizeNilableString(contact.Name),
+			"phone": utils.NormalizeNilableString(contact.Phone),
+		})
+	}
+
+	return results
+}
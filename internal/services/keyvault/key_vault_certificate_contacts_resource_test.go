@@ -0,0 +1,186 @@
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type KeyVaultCertificateContactsResource struct{}
+
+func TestAccKeyVaultCertificateContacts_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_key_vault_certificate_contacts", "test")
+	r := KeyVaultCertificateContactsResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("contact.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKeyVaultCertificateContacts_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_key_vault_certificate_contacts", "test")
+	r := KeyVaultCertificateContactsResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(data),
+			ExpectError: acceptance.RequiresImportError("azurestack_key_vault_certificate_contacts"),
+		},
+	})
+}
+
+func TestAccKeyVaultCertificateContacts_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_key_vault_certificate_contacts", "test")
+	r := KeyVaultCertificateContactsResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("contact.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("contact.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("contact.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (KeyVaultCertificateContactsResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.KeyVault.ManagementClient
+
+	id, err := parse.CertificateContactsID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetCertificateContacts(ctx, id.KeyVaultBaseUrl)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving Certificate Contacts (Key Vault %q): %+v", id.KeyVaultBaseUrl, err)
+	}
+
+	return utils.Bool(resp.ContactList != nil && len(*resp.ContactList) > 0), nil
+}
+
+func (r KeyVaultCertificateContactsResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+%s
+
+resource "azurestack_key_vault_certificate_contacts" "test" {
+  key_vault_id = azurestack_key_vault.test.id
+
+  contact {
+    email = "example@example.com"
+  }
+}
+`, r.template(data))
+}
+
+func (r KeyVaultCertificateContactsResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_key_vault_certificate_contacts" "import" {
+  key_vault_id = azurestack_key_vault_certificate_contacts.test.key_vault_id
+
+  contact {
+    email = "example@example.com"
+  }
+}
+`, r.basic(data))
+}
+
+func (r KeyVaultCertificateContactsResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+%s
+
+resource "azurestack_key_vault_certificate_contacts" "test" {
+  key_vault_id = azurestack_key_vault.test.id
+
+  contact {
+    email = "example@example.com"
+    name  = "example"
+    phone = "01234567890"
+  }
+
+  contact {
+    email = "example2@example.com"
+  }
+}
+`, r.template(data))
+}
+
+func (KeyVaultCertificateContactsResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azurestack_client_config" "current" {}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_key_vault" "test" {
+  name                = "acctestkv-%s"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+  tenant_id           = data.azurestack_client_config.current.tenant_id
+  sku_name            = "standard"
+
+  access_policy {
+    tenant_id = data.azurestack_client_config.current.tenant_id
+    object_id = data.azurestack_client_config.current.service_principal_object_id
+
+    certificate_permissions = [
+      "ManageContacts",
+    ]
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
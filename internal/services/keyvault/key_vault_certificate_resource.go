@@ -0,0 +1,957 @@
+package keyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/parse"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurestack/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func keyVaultCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: keyVaultCertificateCreate,
+		Read:   keyVaultCertificateRead,
+		Update: keyVaultCertificateUpdate,
+		Delete: keyVaultCertificateDelete,
+		Importer: &schema.ResourceImporter{
+			State: nestedItemResourceImporter,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.NestedItemName,
+			},
+
+			"key_vault_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: keyVaultValidate.VaultID,
+			},
+
+			"certificate": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				AtLeastOneOf: []string{"certificate", "certificate_policy"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"contents": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+
+			"certificate_policy": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				AtLeastOneOf: []string{"certificate", "certificate_policy"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"issuer_parameters": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+										// certificates issued by an unknown issuer need the signed certificate merging in
+										// afterwards, which isn't something that can be done within a single apply
+										ValidateFunc: validation.All(
+											validation.StringIsNotEmpty,
+											validation.StringNotInSlice([]string{"Unknown"}, true),
+										),
+									},
+								},
+							},
+						},
+
+						"key_properties": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"exportable": {
+										Type:     schema.TypeBool,
+										Required: true,
+										ForceNew: true,
+									},
+
+									"key_size": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+									},
+
+									"key_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(keyvault.RSA),
+											// string(keyvault.RSAHSM), NOTE: ASH only supports standard keyvault.
+										}, false),
+									},
+
+									"reuse_key": {
+										Type:     schema.TypeBool,
+										Required: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+
+						"lifetime_action": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"action": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"action_type": {
+													Type:     schema.TypeString,
+													Required: true,
+													ForceNew: true,
+													ValidateFunc: validation.StringInSlice([]string{
+														string(keyvault.AutoRenew),
+														string(keyvault.EmailContacts),
+													}, false),
+												},
+											},
+										},
+									},
+
+									"trigger": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"days_before_expiry": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ForceNew:     true,
+													ValidateFunc: validation.IntAtLeast(1),
+												},
+
+												"lifetime_percentage": {
+													Type:         schema.TypeInt,
+													Optional:     true,
+													ForceNew:     true,
+													ValidateFunc: validation.IntBetween(1, 99),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						"secret_properties": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"content_type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											keyVaultCertificateContentTypePKCS12,
+											keyVaultCertificateContentTypePEM,
+										}, false),
+									},
+								},
+							},
+						},
+
+						"x509_certificate_properties": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"extended_key_usage": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Computed: true,
+										ForceNew: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+
+									"key_usage": {
+										Type:     schema.TypeSet,
+										Required: true,
+										ForceNew: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												string(keyvault.CRLSign),
+												string(keyvault.DataEncipherment),
+												string(keyvault.DecipherOnly),
+												string(keyvault.DigitalSignature),
+												string(keyvault.EncipherOnly),
+												string(keyvault.KeyAgreement),
+												string(keyvault.KeyCertSign),
+												string(keyvault.KeyEncipherment),
+												string(keyvault.NonRepudiation),
+											}, false),
+										},
+									},
+
+									"subject": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+
+									"subject_alternative_names": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Computed: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"emails": {
+													Type:     schema.TypeSet,
+													Optional: true,
+													ForceNew: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+
+												"dns_names": {
+													Type:     schema.TypeSet,
+													Optional: true,
+													ForceNew: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+
+												"upns": {
+													Type:     schema.TypeSet,
+													Optional: true,
+													ForceNew: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+													Set: schema.HashString,
+												},
+											},
+										},
+									},
+
+									"validity_in_months": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"tags": tags.Schema(),
+
+			// Computed
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"versionless_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secret_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"versionless_secret_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"thumbprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_data": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_data_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"certificate_pem": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"not_before": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"expires": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+const (
+	keyVaultCertificateContentTypePKCS12 = "application/x-pkcs12"
+	keyVaultCertificateContentTypePEM    = "application/x-pem-file"
+)
+
+func keyVaultCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	keyVaultId, err := parse.VaultID(d.Get("key_vault_id").(string))
+	if err != nil {
+		return err
+	}
+
+	keyVaultBaseUri, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("looking up Certificate %q vault url from id %q: %+v", name, *keyVaultId, err)
+	}
+
+	existing, err := client.GetCertificate(ctx, *keyVaultBaseUri, name, "")
+	if err != nil {
+		if !utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("checking for presence of existing Certificate %q (Key Vault %q): %s", name, *keyVaultBaseUri, err)
+		}
+	}
+
+	if existing.ID != nil && *existing.ID != "" {
+		return tf.ImportAsExistsError("azurestack_key_vault_certificate", *existing.ID)
+	}
+
+	policy := expandKeyVaultCertificatePolicy(d.Get("certificate_policy").([]interface{}))
+	t := d.Get("tags").(map[string]interface{})
+
+	if v := d.Get("certificate").([]interface{}); len(v) > 0 && v[0] != nil {
+		certificate := v[0].(map[string]interface{})
+		contents := certificate["contents"].(string)
+
+		// when no policy is specified Key Vault assumes the contents are a PFX, so PEM contents need to be flagged as such
+		if policy == nil && strings.Contains(contents, "-----BEGIN") {
+			policy = &keyvault.CertificatePolicy{
+				SecretProperties: &keyvault.SecretProperties{
+					ContentType: utils.String(keyVaultCertificateContentTypePEM),
+				},
+			}
+		}
+
+		parameters := keyvault.CertificateImportParameters{
+			Base64EncodedCertificate: utils.String(contents),
+			Password:                 utils.String(certificate["password"].(string)),
+			CertificatePolicy:        policy,
+			Tags:                     tags.Expand(t),
+		}
+		if _, err := client.ImportCertificate(ctx, *keyVaultBaseUri, name, parameters); err != nil {
+			return fmt.Errorf("importing Certificate %q (Key Vault %q): %+v", name, *keyVaultBaseUri, err)
+		}
+	} else {
+		parameters := keyvault.CertificateCreateParameters{
+			CertificatePolicy: policy,
+			Tags:              tags.Expand(t),
+		}
+		if _, err := client.CreateCertificate(ctx, *keyVaultBaseUri, name, parameters); err != nil {
+			return fmt.Errorf("creating Certificate %q (Key Vault %q): %+v", name, *keyVaultBaseUri, err)
+		}
+
+		log.Printf("[DEBUG] Waiting for Certificate %q (Key Vault %q) to be issued..", name, *keyVaultBaseUri)
+		timeout, _ := ctx.Deadline()
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"inProgress"},
+			Target:     []string{"completed"},
+			Refresh:    keyVaultCertificateOperationRefreshFunc(ctx, client, *keyVaultBaseUri, name),
+			MinTimeout: 15 * time.Second,
+			Timeout:    time.Until(timeout),
+		}
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return fmt.Errorf("waiting for Certificate %q (Key Vault %q) to be issued: %+v", name, *keyVaultBaseUri, err)
+		}
+	}
+
+	// "" indicates the latest version
+	read, err := client.GetCertificate(ctx, *keyVaultBaseUri, name, "")
+	if err != nil {
+		return fmt.Errorf("retrieving Certificate %q (Key Vault %q): %+v", name, *keyVaultBaseUri, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("cannot read Certificate %q (Key Vault %q): `id` was nil", name, *keyVaultBaseUri)
+	}
+
+	d.SetId(*read.ID)
+
+	return keyVaultCertificateRead(d, meta)
+}
+
+func keyVaultCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("tags") {
+		parameters := keyvault.CertificateUpdateParameters{
+			Tags: tags.Expand(d.Get("tags").(map[string]interface{})),
+		}
+
+		if _, err := client.UpdateCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
+			return fmt.Errorf("updating Certificate %q (Key Vault %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+		}
+	}
+
+	return keyVaultCertificateRead(d, meta)
+}
+
+func keyVaultCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	resourcesClient := meta.(*clients.Client).Resource
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultIdRaw, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, resourcesClient, id.KeyVaultBaseUrl)
+	if err != nil {
+		return fmt.Errorf("retrieving the Resource ID the Key Vault at URL %q: %s", id.KeyVaultBaseUrl, err)
+	}
+	if keyVaultIdRaw == nil {
+		log.Printf("[DEBUG] Unable to determine the Resource ID for the Key Vault at URL %q - removing from state!", id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+	keyVaultId, err := parse.VaultID(*keyVaultIdRaw)
+	if err != nil {
+		return err
+	}
+
+	ok, err := keyVaultsClient.Exists(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("checking if key vault %q for Certificate %q in Vault at url %q exists: %v", *keyVaultId, id.Name, id.KeyVaultBaseUrl, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] Certificate %q Key Vault %q was not found in Key Vault at URI %q - removing from state", id.Name, *keyVaultId, id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+
+	// we always want to get the latest version
+	resp, err := client.GetCertificate(ctx, id.KeyVaultBaseUrl, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Certificate %q was not found in Key Vault at URI %q - removing from state", id.Name, id.KeyVaultBaseUrl)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("reading Certificate %q (Key Vault %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+	}
+
+	// the version may have changed, so parse the updated id
+	respID, err := parse.ParseNestedItemID(*resp.ID)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", respID.Name)
+	d.Set("key_vault_id", keyVaultId.ID())
+
+	if err := d.Set("certificate_policy", flattenKeyVaultCertificatePolicy(resp.Policy)); err != nil {
+		return fmt.Errorf("setting `certificate_policy`: %+v", err)
+	}
+
+	if err := setKeyVaultCertificateBundle(d, resp); err != nil {
+		return err
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func keyVaultCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	keyVaultsClient := meta.(*clients.Client).KeyVault
+	client := meta.(*clients.Client).KeyVault.ManagementClient
+	resourcesClient := meta.(*clients.Client).Resource
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.ParseNestedItemID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keyVaultIdRaw, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, resourcesClient, id.KeyVaultBaseUrl)
+	if err != nil {
+		return fmt.Errorf("retrieving the Resource ID the Key Vault at URL %q: %s", id.KeyVaultBaseUrl, err)
+	}
+	if keyVaultIdRaw == nil {
+		return fmt.Errorf("Unable to determine the Resource ID for the Key Vault at URL %q", id.KeyVaultBaseUrl)
+	}
+	keyVaultId, err := parse.VaultID(*keyVaultIdRaw)
+	if err != nil {
+		return err
+	}
+
+	ok, err := keyVaultsClient.Exists(ctx, *keyVaultId)
+	if err != nil {
+		return fmt.Errorf("checking if key vault %q for Certificate %q in Vault at url %q exists: %v", *keyVaultId, id.Name, id.KeyVaultBaseUrl, err)
+	}
+	if !ok {
+		log.Printf("[DEBUG] Certificate %q Key Vault %q was not found in Key Vault at URI %q - removing from state", id.Name, *keyVaultId, id.KeyVaultBaseUrl)
+		d.SetId("")
+		return nil
+	}
+
+	shouldPurge := false
+	description := fmt.Sprintf("Certificate %q (Key Vault %q)", id.Name, id.KeyVaultBaseUrl)
+	deleter := deleteAndPurgeCertificate{
+		client:      client,
+		keyVaultUri: id.KeyVaultBaseUrl,
+		name:        id.Name,
+	}
+	if err := deleteAndOptionallyPurge(ctx, description, shouldPurge, deleter); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ deleteAndPurgeNestedItem = deleteAndPurgeCertificate{}
+
+type deleteAndPurgeCertificate struct {
+	client      *keyvault.BaseClient
+	keyVaultUri string
+	name        string
+}
+
+func (d deleteAndPurgeCertificate) DeleteNestedItem(ctx context.Context) (autorest.Response, error) {
+	resp, err := d.client.DeleteCertificate(ctx, d.keyVaultUri, d.name)
+	return resp.Response, err
+}
+
+func (d deleteAndPurgeCertificate) NestedItemHasBeenDeleted(ctx context.Context) (autorest.Response, error) {
+	resp, err := d.client.GetCertificate(ctx, d.keyVaultUri, d.name, "")
+	return resp.Response, err
+}
+
+func (d deleteAndPurgeCertificate) PurgeNestedItem(ctx context.Context) (autorest.Response, error) {
+	return d.client.PurgeDeletedCertificate(ctx, d.keyVaultUri, d.name)
+}
+
+func (d deleteAndPurgeCertificate) NestedItemHasBeenPurged(ctx context.Context) (autorest.Response, error) {
+	resp, err := d.client.GetDeletedCertificate(ctx, d.keyVaultUri, d.name)
+	return resp.Response, err
+}
+
+func keyVaultCertificateOperationRefreshFunc(ctx context.Context, client *keyvault.BaseClient, keyVaultBaseUri string, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		log.Printf("[DEBUG] Checking the status of the operation for Certificate %q (Key Vault %q)", name, keyVaultBaseUri)
+		operation, err := client.GetCertificateOperation(ctx, keyVaultBaseUri, name)
+		if err != nil {
+			return nil, "", fmt.Errorf("retrieving the operation for Certificate %q (Key Vault %q): %+v", name, keyVaultBaseUri, err)
+		}
+
+		if operation.Status == nil {
+			return nil, "", fmt.Errorf("retrieving the operation for Certificate %q (Key Vault %q): `status` was nil", name, keyVaultBaseUri)
+		}
+
+		switch status := *operation.Status; {
+		case strings.EqualFold(status, "inProgress"):
+			return operation, "inProgress", nil
+		case strings.EqualFold(status, "completed"):
+			return operation, "completed", nil
+		default:
+			message := status
+			if operation.StatusDetails != nil {
+				message = fmt.Sprintf("%s: %s", message, *operation.StatusDetails)
+			}
+			if operation.Error != nil && operation.Error.Message != nil {
+				message = fmt.Sprintf("%s: %s", message, *operation.Error.Message)
+			}
+			return operation, status, fmt.Errorf("the operation for Certificate %q (Key Vault %q) didn't complete - %s", name, keyVaultBaseUri, message)
+		}
+	}
+}
+
+func setKeyVaultCertificateBundle(d *schema.ResourceData, input keyvault.CertificateBundle) error {
+	id, err := parse.ParseNestedItemID(*input.ID)
+	if err != nil {
+		return err
+	}
+
+	d.Set("version", id.Version)
+	d.Set("versionless_id", fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(id.KeyVaultBaseUrl, "/"), id.NestedItemType, id.Name))
+
+	secretId := ""
+	versionlessSecretId := ""
+	if input.Sid != nil {
+		secretId = *input.Sid
+		sid, err := parse.ParseNestedItemID(secretId)
+		if err != nil {
+			return err
+		}
+		versionlessSecretId = fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(sid.KeyVaultBaseUrl, "/"), sid.NestedItemType, sid.Name)
+	}
+	d.Set("secret_id", secretId)
+	d.Set("versionless_secret_id", versionlessSecretId)
+
+	thumbprint := ""
+	if input.X509Thumbprint != nil {
+		x509Thumbprint, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(*input.X509Thumbprint, "="))
+		if err != nil {
+			return fmt.Errorf("decoding the thumbprint of Certificate %q: %+v", id.Name, err)
+		}
+		thumbprint = strings.ToUpper(hex.EncodeToString(x509Thumbprint))
+	}
+	d.Set("thumbprint", thumbprint)
+
+	certificateData := ""
+	certificateDataBase64 := ""
+	certificatePem := ""
+	if input.Cer != nil {
+		certificateData = strings.ToUpper(hex.EncodeToString(*input.Cer))
+		certificateDataBase64 = base64.StdEncoding.EncodeToString(*input.Cer)
+		certificatePem = string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: *input.Cer,
+		}))
+	}
+	d.Set("certificate_data", certificateData)
+	d.Set("certificate_data_base64", certificateDataBase64)
+	d.Set("certificate_pem", certificatePem)
+
+	notBefore := ""
+	expires := ""
+	if attributes := input.Attributes; attributes != nil {
+		if v := attributes.NotBefore; v != nil {
+			notBefore = time.Time(*v).Format(time.RFC3339)
+		}
+
+		if v := attributes.Expires; v != nil {
+			expires = time.Time(*v).Format(time.RFC3339)
+		}
+	}
+	d.Set("not_before", notBefore)
+	d.Set("expires", expires)
+
+	return nil
+}
+
+func expandKeyVaultCertificatePolicy(input []interface{}) *keyvault.CertificatePolicy {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	policy := keyvault.CertificatePolicy{}
+
+	if v := raw["issuer_parameters"].([]interface{}); len(v) > 0 && v[0] != nil {
+		issuer := v[0].(map[string]interface{})
+		policy.IssuerParameters = &keyvault.IssuerParameters{
+			Name: utils.String(issuer["name"].(string)),
+		}
+	}
+
+	if v := raw["key_properties"].([]interface{}); len(v) > 0 && v[0] != nil {
+		props := v[0].(map[string]interface{})
+		policy.KeyProperties = &keyvault.KeyProperties{
+			Exportable: utils.Bool(props["exportable"].(bool)),
+			KeySize:    utils.Int32(int32(props["key_size"].(int))),
+			KeyType:    utils.String(props["key_type"].(string)),
+			ReuseKey:   utils.Bool(props["reuse_key"].(bool)),
+		}
+	}
+
+	lifetimeActions := make([]keyvault.LifetimeAction, 0)
+	for _, v := range raw["lifetime_action"].([]interface{}) {
+		if v == nil {
+			continue
+		}
+		action := v.(map[string]interface{})
+		lifetimeAction := keyvault.LifetimeAction{}
+
+		if actions := action["action"].([]interface{}); len(actions) > 0 && actions[0] != nil {
+			actionRaw := actions[0].(map[string]interface{})
+			lifetimeAction.Action = &keyvault.Action{
+				ActionType: keyvault.ActionType(actionRaw["action_type"].(string)),
+			}
+		}
+
+		if triggers := action["trigger"].([]interface{}); len(triggers) > 0 && triggers[0] != nil {
+			triggerRaw := triggers[0].(map[string]interface{})
+			lifetimeAction.Trigger = &keyvault.Trigger{}
+
+			if days := triggerRaw["days_before_expiry"].(int); days > 0 {
+				lifetimeAction.Trigger.DaysBeforeExpiry = utils.Int32(int32(days))
+			}
+
+			if percentage := triggerRaw["lifetime_percentage"].(int); percentage > 0 {
+				lifetimeAction.Trigger.LifetimePercentage = utils.Int32(int32(percentage))
+			}
+		}
+
+		lifetimeActions = append(lifetimeActions, lifetimeAction)
+	}
+	policy.LifetimeActions = &lifetimeActions
+
+	if v := raw["secret_properties"].([]interface{}); len(v) > 0 && v[0] != nil {
+		props := v[0].(map[string]interface{})
+		policy.SecretProperties = &keyvault.SecretProperties{
+			ContentType: utils.String(props["content_type"].(string)),
+		}
+	}
+
+	if v := raw["x509_certificate_properties"].([]interface{}); len(v) > 0 && v[0] != nil {
+		props := v[0].(map[string]interface{})
+
+		extendedKeyUsage := make([]string, 0)
+		for _, usage := range props["extended_key_usage"].([]interface{}) {
+			extendedKeyUsage = append(extendedKeyUsage, usage.(string))
+		}
+
+		keyUsage := make([]keyvault.KeyUsageType, 0)
+		for _, usage := range props["key_usage"].(*schema.Set).List() {
+			keyUsage = append(keyUsage, keyvault.KeyUsageType(usage.(string)))
+		}
+
+		policy.X509CertificateProperties = &keyvault.X509CertificateProperties{
+			Ekus:                    &extendedKeyUsage,
+			KeyUsage:                &keyUsage,
+			Subject:                 utils.String(props["subject"].(string)),
+			SubjectAlternativeNames: expandKeyVaultCertificateSubjectAlternativeNames(props["subject_alternative_names"].([]interface{})),
+			ValidityInMonths:        utils.Int32(int32(props["validity_in_months"].(int))),
+		}
+	}
+
+	return &policy
+}
+
+func expandKeyVaultCertificateSubjectAlternativeNames(input []interface{}) *keyvault.SubjectAlternativeNames {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	return &keyvault.SubjectAlternativeNames{
+		Emails:   utils.ExpandStringSlice(raw["emails"].(*schema.Set).List()),
+		DNSNames: utils.ExpandStringSlice(raw["dns_names"].(*schema.Set).List()),
+		Upns:     utils.ExpandStringSlice(raw["upns"].(*schema.Set).List()),
+	}
+}
+
+func flattenKeyVaultCertificatePolicy(input *keyvault.CertificatePolicy) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	issuerParameters := make([]interface{}, 0)
+	if issuer := input.IssuerParameters; issuer != nil {
+		issuerParameters = append(issuerParameters, map[string]interface{}{
+			"name": utils.NormalizeNilableString(issuer.Name),
+		})
+	}
+
+	keyProperties := make([]interface{}, 0)
+	if props := input.KeyProperties; props != nil {
+		keySize := 0
+		if props.KeySize != nil {
+			keySize = int(*props.KeySize)
+		}
+
+		keyProperties = append(keyProperties, map[string]interface{}{
+			"exportable": utils.NormaliseNilableBool(props.Exportable),
+			"key_size":   keySize,
+			"key_type":   utils.NormalizeNilableString(props.KeyType),
+			"reuse_key":  utils.NormaliseNilableBool(props.ReuseKey),
+		})
+	}
+
+	lifetimeActions := make([]interface{}, 0)
+	if input.LifetimeActions != nil {
+		for _, action := range *input.LifetimeActions {
+			actions := make([]interface{}, 0)
+			if action.Action != nil {
+				actions = append(actions, map[string]interface{}{
+					"action_type": string(action.Action.ActionType),
+				})
+			}
+
+			triggers := make([]interface{}, 0)
+			if trigger := action.Trigger; trigger != nil {
+				daysBeforeExpiry := 0
+				if trigger.DaysBeforeExpiry != nil {
+					daysBeforeExpiry = int(*trigger.DaysBeforeExpiry)
+				}
+
+				lifetimePercentage := 0
+				if trigger.LifetimePercentage != nil {
+					lifetimePercentage = int(*trigger.LifetimePercentage)
+				}
+
+				triggers = append(triggers, map[string]interface{}{
+					"days_before_expiry":  daysBeforeExpiry,
+					"lifetime_percentage": lifetimePercentage,
+				})
+			}
+
+			lifetimeActions = append(lifetimeActions, map[string]interface{}{
+				"action":  actions,
+				"trigger": triggers,
+			})
+		}
+	}
+
+	secretProperties := make([]interface{}, 0)
+	if props := input.SecretProperties; props != nil {
+		secretProperties = append(secretProperties, map[string]interface{}{
+			"content_type": utils.NormalizeNilableString(props.ContentType),
+		})
+	}
+
+	certificateProperties := make([]interface{}, 0)
+	if props := input.X509CertificateProperties; props != nil {
+		extendedKeyUsage := make([]interface{}, 0)
+		if props.Ekus != nil {
+			for _, usage := range *props.Ekus {
+				extendedKeyUsage = append(extendedKeyUsage, usage)
+			}
+		}
+
+		keyUsage := make([]interface{}, 0)
+		if props.KeyUsage != nil {
+			for _, usage := range *props.KeyUsage {
+				keyUsage = append(keyUsage, string(usage))
+			}
+		}
+
+		subjectAlternativeNames := make([]interface{}, 0)
+		if sans := props.SubjectAlternativeNames; sans != nil {
+			subjectAlternativeNames = append(subjectAlternativeNames, map[string]interface{}{
+				"emails":    utils.FlattenStringSlice(sans.Emails),
+				"dns_names": utils.FlattenStringSlice(sans.DNSNames),
+				"upns":      utils.FlattenStringSlice(sans.Upns),
+			})
+		}
+
+		validityInMonths := 0
+		if props.ValidityInMonths != nil {
+			validityInMonths = int(*props.ValidityInMonths)
+		}
+
+		certificateProperties = append(certificateProperties, map[string]interface{}{
+			"extended_key_usage":        extendedKeyUsage,
+			"key_usage":                 keyUsage,
+			"subject":                   utils.NormalizeNilableString(props.Subject),
+			"subject_alternative_names": subjectAlternativeNames,
+			"validity_in_months":        validityInMonths,
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"issuer_parameters":           issuerParameters,
+			"key_properties":              keyProperties,
+			"lifetime_action":             lifetimeActions,
+			"secret_properties":           secretProperties,
+			"x509_certificate_properties": certificateProperties,
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+)
+
+var _ resourceid.Formatter = CertificateIssuerId{}
+
+type CertificateIssuerId struct {
+	KeyVaultBaseUrl string
+	Name            string
+}
+
+func NewCertificateIssuerID(keyVaultBaseUrl, name string) (*CertificateIssuerId, error) {
+	keyVaultUrl, err := url.Parse(keyVaultBaseUrl)
+	if err != nil || keyVaultBaseUrl == "" {
+		return nil, fmt.Errorf("parsing %q: %+v", keyVaultBaseUrl, err)
+	}
+
+	return &CertificateIssuerId{
+		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", keyVaultUrl.Scheme, keyVaultUrl.Hostname()),
+		Name:            name,
+	}, nil
+}
+
+func (id CertificateIssuerId) ID() string {
+	// example: https://tharvey-keyvault.vault.azure.net/certificates/issuers/bird
+	return fmt.Sprintf("%s/certificates/issuers/%s", strings.TrimSuffix(id.KeyVaultBaseUrl, "/"), id.Name)
+}
+
+// CertificateIssuerID parses a Key Vault Certificate Issuer ID into a CertificateIssuerId object
+func CertificateIssuerID(input string) (*CertificateIssuerId, error) {
+	idURL, err := url.ParseRequestURI(input)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse Azure KeyVault Certificate Issuer Id: %s", err)
+	}
+
+	path := strings.TrimSuffix(strings.TrimPrefix(idURL.Path, "/"), "/")
+	components := strings.Split(path, "/")
+	if len(components) != 3 || components[0] != "certificates" || components[1] != "issuers" || components[2] == "" {
+		return nil, fmt.Errorf("expected a KeyVault Certificate Issuer ID in the format `certificates/issuers/{name}` but got %q", path)
+	}
+
+	return &CertificateIssuerId{
+		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
+		Name:            components[2],
+	}, nil
+}
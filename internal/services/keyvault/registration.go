@@ -22,6 +22,7 @@ func (r Registration) WebsiteCategories() []string {
 func (r Registration) SupportedDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
 		"azurestack_key_vault_access_policy": keyVaultAccessPolicyDataSource(),
+		"azurestack_key_vault_certificate":   keyVaultCertificateDataSource(),
 		"azurestack_key_vault_key":           keyVaultKeyDataSource(),
 		"azurestack_key_vault_secret":        keyVaultSecretDataSource(),
 		"azurestack_key_vault":               keyVaultDataSource(),
@@ -31,9 +32,12 @@ func (r Registration) SupportedDataSources() map[string]*schema.Resource {
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"azurestack_key_vault_access_policy": keyVaultAccessPolicy(),
-		"azurestack_key_vault_key":           keyVaultKey(),
-		"azurestack_key_vault_secret":        keyVaultSecret(),
-		"azurestack_key_vault":               keyVault(),
+		"azurestack_key_vault_access_policy":        keyVaultAccessPolicy(),
+		"azurestack_key_vault_certificate":          keyVaultCertificate(),
+		"azurestack_key_vault_certificate_contacts": keyVaultCertificateContacts(),
+		"azurestack_key_vault_certificate_issuer":   keyVaultCertificateIssuer(),
+		"azurestack_key_vault_key":                  keyVaultKey(),
+		"azurestack_key_vault_secret":               keyVaultSecret(),
+		"azurestack_key_vault":                      keyVault(),
 	}
 }
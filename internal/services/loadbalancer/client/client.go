@@ -6,9 +6,10 @@ import (
 )
 
 type Client struct {
-	LoadBalancersClient                   *network.LoadBalancersClient
-	LoadBalancerBackendAddressPoolsClient *network.LoadBalancerBackendAddressPoolsClient
-	LoadBalancingRulesClient              *network.LoadBalancerLoadBalancingRulesClient
+	LoadBalancersClient                       *network.LoadBalancersClient
+	LoadBalancerBackendAddressPoolsClient     *network.LoadBalancerBackendAddressPoolsClient
+	LoadBalancerFrontendIPConfigurationClient *network.LoadBalancerFrontendIPConfigurationsClient
+	LoadBalancingRulesClient                  *network.LoadBalancerLoadBalancingRulesClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
@@ -18,12 +19,16 @@ func NewClient(o *common.ClientOptions) *Client {
 	loadBalancerBackendAddressPoolsClient := network.NewLoadBalancerBackendAddressPoolsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&loadBalancerBackendAddressPoolsClient.Client, o.ResourceManagerAuthorizer)
 
+	loadBalancerFrontendIPConfigurationClient := network.NewLoadBalancerFrontendIPConfigurationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&loadBalancerFrontendIPConfigurationClient.Client, o.ResourceManagerAuthorizer)
+
 	loadBalancingRulesClient := network.NewLoadBalancerLoadBalancingRulesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&loadBalancingRulesClient.Client, o.ResourceManagerAuthorizer)
 
 	return &Client{
-		LoadBalancersClient:                   &loadBalancersClient,
-		LoadBalancerBackendAddressPoolsClient: &loadBalancerBackendAddressPoolsClient,
-		LoadBalancingRulesClient:              &loadBalancingRulesClient,
+		LoadBalancersClient:                       &loadBalancersClient,
+		LoadBalancerBackendAddressPoolsClient:     &loadBalancerBackendAddressPoolsClient,
+		LoadBalancerFrontendIPConfigurationClient: &loadBalancerFrontendIPConfigurationClient,
+		LoadBalancingRulesClient:                  &loadBalancingRulesClient,
 	}
 }
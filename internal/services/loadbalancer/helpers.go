@@ -25,18 +25,18 @@ func FindLoadBalancerBackEndAddressPoolByName(lb *network.LoadBalancer, name str
 	return nil, -1, false
 }
 
-func FindLoadBalancerFrontEndIpConfigurationByName(lb *network.LoadBalancer, name string) (*network.FrontendIPConfiguration, bool) {
+func FindLoadBalancerFrontEndIpConfigurationByName(lb *network.LoadBalancer, name string) (*network.FrontendIPConfiguration, int, bool) {
 	if lb == nil || lb.LoadBalancerPropertiesFormat == nil || lb.LoadBalancerPropertiesFormat.FrontendIPConfigurations == nil {
-		return nil, false
+		return nil, -1, false
 	}
 
-	for _, feip := range *lb.LoadBalancerPropertiesFormat.FrontendIPConfigurations {
+	for i, feip := range *lb.LoadBalancerPropertiesFormat.FrontendIPConfigurations {
 		if feip.Name != nil && *feip.Name == name {
-			return &feip, true
+			return &feip, i, true
 		}
 	}
 
-	return nil, false
+	return nil, -1, false
 }
 
 func FindLoadBalancerRuleByName(lb *network.LoadBalancer, name string) (*network.LoadBalancingRule, int, bool) {
@@ -0,0 +1,37 @@
+package loadbalancer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+func TestAccLoadBalancerFrontendIpConfigurationDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_lb_frontend_ip_configuration", "test")
+	r := LoadBalancerFrontendIpConfiguration{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basicDataSource(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").Exists(),
+				check.That(data.ResourceName).Key("subnet_id").Exists(),
+				check.That(data.ResourceName).Key("private_ip_address").Exists(),
+				check.That(data.ResourceName).Key("private_ip_address_allocation").HasValue("Dynamic"),
+			),
+		},
+	})
+}
+
+func (r LoadBalancerFrontendIpConfiguration) basicDataSource(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_lb_frontend_ip_configuration" "test" {
+  name            = azurestack_lb_frontend_ip_configuration.test.name
+  loadbalancer_id = azurestack_lb_frontend_ip_configuration.test.loadbalancer_id
+}
+`, r.basic(data))
+}
@@ -0,0 +1,343 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/loadbalancer/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/loadbalancer/validate"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/state"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func loadBalancerFrontendIpConfiguration() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: loadBalancerFrontendIpConfigurationCreateUpdate,
+		Read:   loadBalancerFrontendIpConfigurationRead,
+		Update: loadBalancerFrontendIpConfigurationCreateUpdate,
+		Delete: loadBalancerFrontendIpConfigurationDelete,
+
+		Importer: loadBalancerSubResourceImporter(func(input string) (*parse.LoadBalancerId, error) {
+			id, err := parse.LoadBalancerFrontendIpConfigurationID(input)
+			if err != nil {
+				return nil, err
+			}
+
+			lbId := parse.NewLoadBalancerID(id.SubscriptionId, id.ResourceGroup, id.LoadBalancerName)
+			return &lbId, nil
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"loadbalancer_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.LoadBalancerID,
+			},
+
+			"subnet_id": {
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  resourceid.ValidateResourceIDOrEmpty,
+				ConflictsWith: []string{"public_ip_address_id"},
+			},
+
+			"private_ip_address": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.Any(
+					validation.IsIPAddress,
+					validation.StringIsEmpty,
+				),
+			},
+
+			"private_ip_address_allocation": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.Dynamic),
+					string(network.Static),
+				}, true),
+				StateFunc:        state.IgnoreCase,
+				DiffSuppressFunc: suppress.CaseDifference,
+			},
+
+			"public_ip_address_id": {
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  resourceid.ValidateResourceIDOrEmpty,
+				ConflictsWith: []string{"subnet_id"},
+			},
+
+			"load_balancer_rules": {
+				Type:     pluginsdk.TypeSet,
+				Computed: true,
+				Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+				Set:      pluginsdk.HashString,
+			},
+
+			"inbound_nat_rules": {
+				Type:     pluginsdk.TypeSet,
+				Computed: true,
+				Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+				Set:      pluginsdk.HashString,
+			},
+
+			"outbound_rules": {
+				Type:     pluginsdk.TypeSet,
+				Computed: true,
+				Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+				Set:      pluginsdk.HashString,
+			},
+		},
+	}
+}
+
+func loadBalancerFrontendIpConfigurationCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).LoadBalancer.LoadBalancersClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	loadBalancerId, err := parse.LoadBalancerID(d.Get("loadbalancer_id").(string))
+	if err != nil {
+		return err
+	}
+	loadBalancerIDRaw := loadBalancerId.ID()
+	id := parse.NewLoadBalancerFrontendIpConfigurationID(subscriptionId, loadBalancerId.ResourceGroup, loadBalancerId.Name, d.Get("name").(string))
+	locks.ByID(loadBalancerIDRaw)
+	defer locks.UnlockByID(loadBalancerIDRaw)
+
+	loadBalancer, err := client.Get(ctx, loadBalancerId.ResourceGroup, loadBalancerId.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(loadBalancer.Response) {
+			d.SetId("")
+			log.Printf("[INFO] Load Balancer %q not found. Removing Frontend IP Configuration %q from state", id.LoadBalancerName, id.FrontendIPConfigurationName)
+			return nil
+		}
+		return fmt.Errorf("failed to retrieve Load Balancer %q (resource group %q) for Frontend IP Configuration %q: %+v", id.LoadBalancerName, id.ResourceGroup, id.FrontendIPConfigurationName, err)
+	}
+
+	newFrontendIPConfiguration := expandazurestackLoadBalancerFrontendIpConfiguration(d)
+
+	frontendIPConfigurations := make([]network.FrontendIPConfiguration, 0)
+	if loadBalancer.LoadBalancerPropertiesFormat.FrontendIPConfigurations != nil {
+		frontendIPConfigurations = *loadBalancer.LoadBalancerPropertiesFormat.FrontendIPConfigurations
+	}
+
+	existingFrontendIPConfiguration, existingFrontendIPConfigurationIndex, exists := FindLoadBalancerFrontEndIpConfigurationByName(&loadBalancer, id.FrontendIPConfigurationName)
+	if exists {
+		if d.IsNewResource() {
+			return tf.ImportAsExistsError("azurestack_lb_frontend_ip_configuration", *existingFrontendIPConfiguration.ID)
+		}
+
+		// this frontend ip configuration is being updated/reapplied - replace it in place so that the
+		// rules referencing it by position remain valid
+		frontendIPConfigurations[existingFrontendIPConfigurationIndex] = *newFrontendIPConfiguration
+	} else {
+		frontendIPConfigurations = append(frontendIPConfigurations, *newFrontendIPConfiguration)
+	}
+
+	loadBalancer.LoadBalancerPropertiesFormat.FrontendIPConfigurations = &frontendIPConfigurations
+
+	future, err := client.CreateOrUpdate(ctx, loadBalancerId.ResourceGroup, loadBalancerId.Name, loadBalancer)
+	if err != nil {
+		return fmt.Errorf("updating Load Balancer %q (Resource Group %q) for Frontend IP Configuration %q: %+v", id.LoadBalancerName, id.ResourceGroup, id.FrontendIPConfigurationName, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of Load Balancer %q (Resource Group %q) for Frontend IP Configuration %q: %+v", id.LoadBalancerName, id.ResourceGroup, id.FrontendIPConfigurationName, err)
+	}
+
+	d.SetId(id.ID())
+
+	return loadBalancerFrontendIpConfigurationRead(d, meta)
+}
+
+func loadBalancerFrontendIpConfigurationRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).LoadBalancer.LoadBalancersClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.LoadBalancerFrontendIpConfigurationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	loadBalancer, err := client.Get(ctx, id.ResourceGroup, id.LoadBalancerName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(loadBalancer.Response) {
+			d.SetId("")
+			log.Printf("[INFO] Load Balancer %q not found. Removing from state", id.LoadBalancerName)
+			return nil
+		}
+		return fmt.Errorf("failed to retrieve Load Balancer %q (resource group %q) for Frontend IP Configuration %q: %+v", id.LoadBalancerName, id.ResourceGroup, id.FrontendIPConfigurationName, err)
+	}
+
+	config, _, exists := FindLoadBalancerFrontEndIpConfigurationByName(&loadBalancer, id.FrontendIPConfigurationName)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] Load Balancer Frontend IP Configuration %q not found. Removing from state", id.FrontendIPConfigurationName)
+		return nil
+	}
+
+	loadBalancerId := parse.NewLoadBalancerID(id.SubscriptionId, id.ResourceGroup, id.LoadBalancerName)
+	d.Set("name", config.Name)
+	d.Set("loadbalancer_id", loadBalancerId.ID())
+
+	return setLoadBalancerFrontendIpConfigurationProperties(d, config.FrontendIPConfigurationPropertiesFormat)
+}
+
+func loadBalancerFrontendIpConfigurationDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).LoadBalancer.LoadBalancersClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := parse.LoadBalancerFrontendIpConfigurationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	loadBalancerId := parse.NewLoadBalancerID(id.SubscriptionId, id.ResourceGroup, id.LoadBalancerName)
+	loadBalancerID := loadBalancerId.ID()
+	locks.ByID(loadBalancerID)
+	defer locks.UnlockByID(loadBalancerID)
+
+	loadBalancer, err := client.Get(ctx, loadBalancerId.ResourceGroup, loadBalancerId.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(loadBalancer.Response) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("failed to retrieve Load Balancer %q (resource group %q) for Frontend IP Configuration %q: %+v", loadBalancerId.Name, loadBalancerId.ResourceGroup, id.FrontendIPConfigurationName, err)
+	}
+
+	_, index, exists := FindLoadBalancerFrontEndIpConfigurationByName(&loadBalancer, id.FrontendIPConfigurationName)
+	if !exists {
+		return nil
+	}
+
+	frontendIPConfigurations := *loadBalancer.LoadBalancerPropertiesFormat.FrontendIPConfigurations
+	frontendIPConfigurations = append(frontendIPConfigurations[:index], frontendIPConfigurations[index+1:]...)
+	loadBalancer.LoadBalancerPropertiesFormat.FrontendIPConfigurations = &frontendIPConfigurations
+
+	future, err := client.CreateOrUpdate(ctx, id.ResourceGroup, id.LoadBalancerName, loadBalancer)
+	if err != nil {
+		return fmt.Errorf("updating Load Balancer %q (Resource Group %q) for deletion of Frontend IP Configuration %q: %+v", id.LoadBalancerName, id.ResourceGroup, id.FrontendIPConfigurationName, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for update of Load Balancer %q (Resource Group %q) for deletion of Frontend IP Configuration %q: %+v", id.LoadBalancerName, id.ResourceGroup, id.FrontendIPConfigurationName, err)
+	}
+
+	return nil
+}
+
+func expandazurestackLoadBalancerFrontendIpConfiguration(d *pluginsdk.ResourceData) *network.FrontendIPConfiguration {
+	properties := network.FrontendIPConfigurationPropertiesFormat{
+		PrivateIPAllocationMethod: network.IPAllocationMethod(d.Get("private_ip_address_allocation").(string)),
+	}
+
+	if v := d.Get("private_ip_address").(string); v != "" {
+		properties.PrivateIPAddress = pointer.FromString(v)
+	}
+
+	if v := d.Get("public_ip_address_id").(string); v != "" {
+		properties.PublicIPAddress = &network.PublicIPAddress{
+			ID: pointer.FromString(v),
+		}
+	}
+
+	if v := d.Get("subnet_id").(string); v != "" {
+		properties.Subnet = &network.Subnet{
+			ID: pointer.FromString(v),
+		}
+	}
+
+	return &network.FrontendIPConfiguration{
+		Name:                                    pointer.FromString(d.Get("name").(string)),
+		FrontendIPConfigurationPropertiesFormat: &properties,
+	}
+}
+
+func setLoadBalancerFrontendIpConfigurationProperties(d *pluginsdk.ResourceData, props *network.FrontendIPConfigurationPropertiesFormat) error {
+	if props == nil {
+		return nil
+	}
+
+	d.Set("private_ip_address_allocation", string(props.PrivateIPAllocationMethod))
+
+	subnetId := ""
+	if props.Subnet != nil && props.Subnet.ID != nil {
+		subnetId = *props.Subnet.ID
+	}
+	d.Set("subnet_id", subnetId)
+
+	privateIPAddress := ""
+	if props.PrivateIPAddress != nil {
+		privateIPAddress = *props.PrivateIPAddress
+	}
+	d.Set("private_ip_address", privateIPAddress)
+
+	publicIPAddressId := ""
+	if props.PublicIPAddress != nil && props.PublicIPAddress.ID != nil {
+		publicIPAddressId = *props.PublicIPAddress.ID
+	}
+	d.Set("public_ip_address_id", publicIPAddressId)
+
+	if err := d.Set("load_balancer_rules", flattenLoadBalancerSubResourceIDs(props.LoadBalancingRules)); err != nil {
+		return fmt.Errorf("setting `load_balancer_rules`: %+v", err)
+	}
+
+	if err := d.Set("inbound_nat_rules", flattenLoadBalancerSubResourceIDs(props.InboundNatRules)); err != nil {
+		return fmt.Errorf("setting `inbound_nat_rules`: %+v", err)
+	}
+
+	if err := d.Set("outbound_rules", flattenLoadBalancerSubResourceIDs(props.OutboundRules)); err != nil {
+		return fmt.Errorf("setting `outbound_rules`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenLoadBalancerSubResourceIDs(input *[]network.SubResource) *pluginsdk.Set {
+	results := make([]interface{}, 0)
+	if input != nil {
+		for _, item := range *input {
+			if item.ID != nil {
+				results = append(results, *item.ID)
+			}
+		}
+	}
+
+	return pluginsdk.NewSet(pluginsdk.HashString, results)
+}
@@ -0,0 +1,290 @@
+package loadbalancer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/loadbalancer/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+type LoadBalancerFrontendIpConfiguration struct{}
+
+func TestAccLoadBalancerFrontendIpConfiguration_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_lb_frontend_ip_configuration", "test")
+	r := LoadBalancerFrontendIpConfiguration{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("private_ip_address").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLoadBalancerFrontendIpConfiguration_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_lb_frontend_ip_configuration", "test")
+	r := LoadBalancerFrontendIpConfiguration{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccLoadBalancerFrontendIpConfiguration_disappears(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_lb_frontend_ip_configuration", "test")
+	r := LoadBalancerFrontendIpConfiguration{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		data.DisappearsStep(acceptance.DisappearsStepData{
+			Config:       r.basic,
+			TestResource: r,
+		}),
+	})
+}
+
+func TestAccLoadBalancerFrontendIpConfiguration_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_lb_frontend_ip_configuration", "test")
+	r := LoadBalancerFrontendIpConfiguration{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.staticPrivateIPAddress(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("private_ip_address_allocation").HasValue("static"),
+				check.That(data.ResourceName).Key("private_ip_address").HasValue("10.0.2.10"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLoadBalancerFrontendIpConfiguration_withInline(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_lb_frontend_ip_configuration", "test")
+	r := LoadBalancerFrontendIpConfiguration{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.withInline(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurestack_lb.test").Key("frontend_ip_configuration.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// updating the Load Balancer mustn't remove the standalone Frontend IP Configuration
+			Config: r.withInline(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurestack_lb.test").Key("frontend_ip_configuration.#").HasValue("1"),
+				check.That("azurestack_lb.test").Key("tags.environment").HasValue("second"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r LoadBalancerFrontendIpConfiguration) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.LoadBalancerFrontendIpConfigurationID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.LoadBalancer.LoadBalancerFrontendIPConfigurationClient.Get(ctx, id.ResourceGroup, id.LoadBalancerName, id.FrontendIPConfigurationName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.FromBool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.FromBool(resp.ID != nil), nil
+}
+
+func (r LoadBalancerFrontendIpConfiguration) Destroy(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.LoadBalancerFrontendIpConfigurationID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := client.LoadBalancer.LoadBalancersClient.Get(ctx, id.ResourceGroup, id.LoadBalancerName, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Load Balancer %q (Resource Group %q)", id.LoadBalancerName, id.ResourceGroup)
+	}
+	if lb.LoadBalancerPropertiesFormat == nil {
+		return nil, fmt.Errorf("`properties` was nil")
+	}
+	if lb.LoadBalancerPropertiesFormat.FrontendIPConfigurations == nil {
+		return nil, fmt.Errorf("`properties.FrontendIPConfigurations` was nil")
+	}
+
+	configs := make([]network.FrontendIPConfiguration, 0)
+	for _, config := range *lb.LoadBalancerPropertiesFormat.FrontendIPConfigurations {
+		if config.Name == nil || *config.Name == id.FrontendIPConfigurationName {
+			continue
+		}
+
+		configs = append(configs, config)
+	}
+	lb.LoadBalancerPropertiesFormat.FrontendIPConfigurations = &configs
+
+	future, err := client.LoadBalancer.LoadBalancersClient.CreateOrUpdate(ctx, id.ResourceGroup, id.LoadBalancerName, lb)
+	if err != nil {
+		return nil, fmt.Errorf("updating Load Balancer %q (Resource Group %q): %+v", id.LoadBalancerName, id.ResourceGroup, err)
+	}
+
+	if err := future.WaitForCompletionRef(ctx, client.LoadBalancer.LoadBalancersClient.Client); err != nil {
+		return nil, fmt.Errorf("waiting for update of Load Balancer %q (Resource Group %q): %+v", id.LoadBalancerName, id.ResourceGroup, err)
+	}
+
+	return pointer.FromBool(true), nil
+}
+
+func (r LoadBalancerFrontendIpConfiguration) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-lb-%d"
+  location = "%s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "acctsub-%d"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurestack_lb" "test" {
+  name                = "acctestlb-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (r LoadBalancerFrontendIpConfiguration) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_lb_frontend_ip_configuration" "test" {
+  name                          = "Internal-%d"
+  loadbalancer_id               = azurestack_lb.test.id
+  subnet_id                     = azurestack_subnet.test.id
+  private_ip_address_allocation = "Dynamic"
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r LoadBalancerFrontendIpConfiguration) staticPrivateIPAddress(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_lb_frontend_ip_configuration" "test" {
+  name                          = "Internal-%d"
+  loadbalancer_id               = azurestack_lb.test.id
+  subnet_id                     = azurestack_subnet.test.id
+  private_ip_address_allocation = "Static"
+  private_ip_address            = "10.0.2.10"
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r LoadBalancerFrontendIpConfiguration) withInline(data acceptance.TestData, environment string) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-lb-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctvn-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "acctsub-%[1]d"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurestack_lb" "test" {
+  name                = "acctestlb-%[1]d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+
+  frontend_ip_configuration {
+    name                          = "Inline-%[1]d"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+
+  tags = {
+    environment = "%[3]s"
+  }
+}
+
+resource "azurestack_lb_frontend_ip_configuration" "test" {
+  name                          = "Internal-%[1]d"
+  loadbalancer_id               = azurestack_lb.test.id
+  subnet_id                     = azurestack_subnet.test.id
+  private_ip_address_allocation = "Dynamic"
+}
+`, data.RandomInteger, data.Locations.Primary, environment)
+}
+
+func (r LoadBalancerFrontendIpConfiguration) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_lb_frontend_ip_configuration" "import" {
+  name                          = azurestack_lb_frontend_ip_configuration.test.name
+  loadbalancer_id               = azurestack_lb_frontend_ip_configuration.test.loadbalancer_id
+  subnet_id                     = azurestack_lb_frontend_ip_configuration.test.subnet_id
+  private_ip_address_allocation = "Dynamic"
+}
+`, r.basic(data))
+}
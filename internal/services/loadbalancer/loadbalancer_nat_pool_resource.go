@@ -289,7 +289,7 @@ func expandazurestackLoadBalancerNatPool(d *pluginsdk.ResourceData, lb *network.
 	}
 
 	if v := d.Get("frontend_ip_configuration_name").(string); v != "" {
-		rule, exists := FindLoadBalancerFrontEndIpConfigurationByName(lb, v)
+		rule, _, exists := FindLoadBalancerFrontEndIpConfigurationByName(lb, v)
 		if !exists {
 			return nil, fmt.Errorf("[ERROR] Cannot find FrontEnd IP Configuration with the name %s", v)
 		}
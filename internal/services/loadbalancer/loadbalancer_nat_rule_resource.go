@@ -314,7 +314,7 @@ func expandazurestackLoadBalancerNatRule(d *pluginsdk.ResourceData, lb *network.
 	}
 
 	if v := d.Get("frontend_ip_configuration_name").(string); v != "" {
-		if _, exists := FindLoadBalancerFrontEndIpConfigurationByName(lb, v); !exists {
+		if _, _, exists := FindLoadBalancerFrontEndIpConfigurationByName(lb, v); !exists {
 			return nil, fmt.Errorf("[ERROR] Cannot find FrontEnd IP Configuration with the name %s", v)
 		}
 
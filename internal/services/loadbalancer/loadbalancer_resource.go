@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/resourceid"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/services/loadbalancer/parse"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf"
 	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
@@ -30,10 +31,10 @@ func loadBalancer() *pluginsdk.Resource {
 		Update: loadBalancerCreateUpdate,
 		Delete: loadBalancerDelete,
 
-		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+		Importer: pluginsdk.ImporterValidatingResourceIdThen(func(id string) error {
 			_, err := parse.LoadBalancerID(id)
 			return err
-		}),
+		}, loadBalancerImport),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
@@ -201,6 +202,42 @@ func loadBalancerCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 		properties.FrontendIPConfigurations = frontendIPConfigurations
 	}
 
+	if !d.IsNewResource() {
+		// the Frontend IP Configurations managed by the `azurestack_lb_frontend_ip_configuration` resource
+		// are updated via the Load Balancer too, so these are locked and retained
+		locks.ByID(id.ID())
+		defer locks.UnlockByID(id.ID())
+
+		existing, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+
+		oldRaw, newRaw := d.GetChange("frontend_ip_configuration")
+		inline := loadBalancerFrontendIpConfigurationNames(oldRaw.([]interface{}))
+		for name := range loadBalancerFrontendIpConfigurationNames(newRaw.([]interface{})) {
+			inline[name] = struct{}{}
+		}
+
+		if props := existing.LoadBalancerPropertiesFormat; props != nil && props.FrontendIPConfigurations != nil {
+			frontendIPConfigurations := make([]network.FrontendIPConfiguration, 0)
+			if properties.FrontendIPConfigurations != nil {
+				frontendIPConfigurations = *properties.FrontendIPConfigurations
+			}
+
+			for _, config := range *props.FrontendIPConfigurations {
+				if config.Name == nil {
+					continue
+				}
+				if _, ok := inline[*config.Name]; !ok {
+					frontendIPConfigurations = append(frontendIPConfigurations, config)
+				}
+			}
+
+			properties.FrontendIPConfigurations = &frontendIPConfigurations
+		}
+	}
+
 	loadBalancer := network.LoadBalancer{
 		Name:     pointer.FromString(id.Name),
 		Location: pointer.FromString(location.Normalize(d.Get("location").(string))),
@@ -254,7 +291,20 @@ func loadBalancerRead(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	if props := resp.LoadBalancerPropertiesFormat; props != nil {
 		if feipConfigs := props.FrontendIPConfigurations; feipConfigs != nil {
-			if err := d.Set("frontend_ip_configuration", flattenLoadBalancerFrontendIpConfiguration(feipConfigs)); err != nil {
+			// only the Frontend IP Configurations defined in-line are tracked, since any others are managed
+			// by the `azurestack_lb_frontend_ip_configuration` resource
+			inline := loadBalancerFrontendIpConfigurationNames(d.Get("frontend_ip_configuration").([]interface{}))
+			inlineConfigs := make([]network.FrontendIPConfiguration, 0)
+			for _, config := range *feipConfigs {
+				if config.Name == nil {
+					continue
+				}
+				if _, ok := inline[*config.Name]; ok {
+					inlineConfigs = append(inlineConfigs, config)
+				}
+			}
+
+			if err := d.Set("frontend_ip_configuration", flattenLoadBalancerFrontendIpConfiguration(&inlineConfigs)); err != nil {
 				return fmt.Errorf("flattening `frontend_ip_configuration`: %+v", err)
 			}
 
@@ -280,6 +330,30 @@ func loadBalancerRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
+func loadBalancerImport(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+	client := meta.(*clients.Client).LoadBalancer.LoadBalancersClient
+
+	id, err := parse.LoadBalancerID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	// all of the Frontend IP Configurations are imported as in-line, since which are
+	// managed by the `azurestack_lb_frontend_ip_configuration` resource isn't known
+	if props := resp.LoadBalancerPropertiesFormat; props != nil && props.FrontendIPConfigurations != nil {
+		if err := d.Set("frontend_ip_configuration", flattenLoadBalancerFrontendIpConfiguration(props.FrontendIPConfigurations)); err != nil {
+			return nil, fmt.Errorf("flattening `frontend_ip_configuration`: %+v", err)
+		}
+	}
+
+	return []*pluginsdk.ResourceData{d}, nil
+}
+
 func loadBalancerDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).LoadBalancer.LoadBalancersClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
@@ -343,6 +417,18 @@ func expandazurestackLoadBalancerFrontendIpConfigurations(d *pluginsdk.ResourceD
 	return &frontEndConfigs
 }
 
+// loadBalancerFrontendIpConfigurationNames returns the names of the specified `frontend_ip_configuration` blocks
+func loadBalancerFrontendIpConfigurationNames(input []interface{}) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, raw := range input {
+		if v, ok := raw.(map[string]interface{}); ok {
+			names[v["name"].(string)] = struct{}{}
+		}
+	}
+
+	return names
+}
+
 func flattenLoadBalancerFrontendIpConfiguration(ipConfigs *[]network.FrontendIPConfiguration) []interface{} {
 	result := make([]interface{}, 0)
 	if ipConfigs == nil {
@@ -356,7 +356,7 @@ func expandazurestackLoadBalancerRule(d *pluginsdk.ResourceData, lb *network.Loa
 
 	// TODO: ensure these ID's are consistent
 	if v := d.Get("frontend_ip_configuration_name").(string); v != "" {
-		rule, exists := FindLoadBalancerFrontEndIpConfigurationByName(lb, v)
+		rule, _, exists := FindLoadBalancerFrontEndIpConfigurationByName(lb, v)
 		if !exists {
 			return nil, fmt.Errorf("[ERROR] Cannot find FrontEnd IP Configuration with the name %s", v)
 		}
@@ -0,0 +1,199 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func loadBalancersDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: loadBalancersDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"resource_group_name": commonschema.ResourceGroupNameOptional(),
+
+			"name_prefix": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"required_tags": tags.Schema(),
+
+			"attachment_status": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Attached",
+					"Unattached",
+				}, false),
+			},
+
+			"load_balancers": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"location": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"sku": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"private_ip_addresses": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+						"public_ip_address_ids": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+						"tags": tags.SchemaDataSource(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func loadBalancersDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).LoadBalancer.LoadBalancersClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	var iterator network.LoadBalancerListResultIterator
+	var err error
+	if resourceGroup != "" {
+		log.Printf("[DEBUG] Reading Load Balancers in Resource Group %q", resourceGroup)
+		iterator, err = client.ListComplete(ctx, resourceGroup)
+	} else {
+		log.Printf("[DEBUG] Reading Load Balancers in the Subscription")
+		iterator, err = client.ListAllComplete(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("listing Load Balancers: %+v", err)
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	requiredTags := d.Get("required_tags").(map[string]interface{})
+	attachmentStatus := d.Get("attachment_status").(string)
+
+	results := make([]interface{}, 0)
+	for iterator.NotDone() {
+		element := iterator.Value()
+
+		// a Load Balancer is considered attached when any of its Backend Address Pools contain an IP Configuration
+		isAttached := false
+		if props := element.LoadBalancerPropertiesFormat; props != nil && props.BackendAddressPools != nil {
+			for _, pool := range *props.BackendAddressPools {
+				if pool.BackendAddressPoolPropertiesFormat != nil && pool.BackendAddressPoolPropertiesFormat.BackendIPConfigurations != nil && len(*pool.BackendAddressPoolPropertiesFormat.BackendIPConfigurations) > 0 {
+					isAttached = true
+					break
+				}
+			}
+		}
+
+		include := true
+		if namePrefix != "" && (element.Name == nil || !strings.HasPrefix(*element.Name, namePrefix)) {
+			include = false
+		}
+		if !tags.MatchesAll(element.Tags, requiredTags) {
+			include = false
+		}
+		if attachmentStatus == "Attached" && !isAttached {
+			include = false
+		}
+		if attachmentStatus == "Unattached" && isAttached {
+			include = false
+		}
+
+		if include {
+			results = append(results, flattenDataSourceLoadBalancer(element))
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("enumerating Load Balancers: %+v", err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("load_balancers", results); err != nil {
+		return fmt.Errorf("setting `load_balancers`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenDataSourceLoadBalancer(input network.LoadBalancer) map[string]interface{} {
+	id := ""
+	if input.ID != nil {
+		id = *input.ID
+	}
+
+	name := ""
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	sku := ""
+	if input.Sku != nil {
+		sku = string(input.Sku.Name)
+	}
+
+	privateIPAddresses := make([]interface{}, 0)
+	publicIPAddressIds := make([]interface{}, 0)
+	if props := input.LoadBalancerPropertiesFormat; props != nil && props.FrontendIPConfigurations != nil {
+		for _, config := range *props.FrontendIPConfigurations {
+			if config.FrontendIPConfigurationPropertiesFormat == nil {
+				continue
+			}
+
+			if v := config.FrontendIPConfigurationPropertiesFormat.PrivateIPAddress; v != nil && *v != "" {
+				privateIPAddresses = append(privateIPAddresses, *v)
+			}
+
+			if v := config.FrontendIPConfigurationPropertiesFormat.PublicIPAddress; v != nil && v.ID != nil {
+				publicIPAddressIds = append(publicIPAddressIds, *v.ID)
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                    id,
+		"name":                  name,
+		"location":              location.NormalizeNilable(input.Location),
+		"sku":                   sku,
+		"private_ip_addresses":  privateIPAddresses,
+		"public_ip_address_ids": publicIPAddressIds,
+		"tags":                  tags.Flatten(input.Tags),
+	}
+}
@@ -0,0 +1,107 @@
+package loadbalancer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type LoadBalancersDataSource struct{}
+
+func TestAccLoadBalancersDataSource_namePrefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_load_balancers", "test")
+	r := LoadBalancersDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.namePrefix(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("load_balancers.#").HasValue("2"),
+				check.That(data.ResourceName).Key("load_balancers.0.name").HasValue(fmt.Sprintf("acctestlba%d-0", data.RandomInteger)),
+				check.That(data.ResourceName).Key("load_balancers.0.sku").HasValue("Basic"),
+			),
+		},
+	})
+}
+
+func TestAccLoadBalancersDataSource_requiredTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_load_balancers", "test")
+	r := LoadBalancersDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.requiredTags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("load_balancers.#").HasValue("1"),
+				check.That(data.ResourceName).Key("load_balancers.0.name").HasValue(fmt.Sprintf("acctestlbb%d", data.RandomInteger)),
+			),
+		},
+	})
+}
+
+func (LoadBalancersDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_lb" "test" {
+  count               = 2
+  name                = "acctestlba%d-${count.index}"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  tags = {
+    environment = "test"
+  }
+}
+
+resource "azurestack_lb" "test2" {
+  name                = "acctestlbb%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  tags = {
+    environment = "production"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
+func (r LoadBalancersDataSource) namePrefix(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_load_balancers" "test" {
+  resource_group_name = azurestack_resource_group.test.name
+  name_prefix         = "acctestlba"
+}
+`, r.template(data))
+}
+
+func (r LoadBalancersDataSource) requiredTags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_load_balancers" "test" {
+  resource_group_name = azurestack_resource_group.test.name
+
+  required_tags = {
+    environment = "production"
+  }
+}
+`, r.template(data))
+}
@@ -7,6 +7,7 @@ import (
 
 type Client struct {
 	ApplicationSecurityGroupsClient *network.ApplicationSecurityGroupsClient
+	DefaultSecurityRulesClient      *network.DefaultSecurityRulesClient
 	InterfacesClient                *network.InterfacesClient
 	LocalNetworkGatewaysClient      *network.LocalNetworkGatewaysClient
 	PublicIPsClient                 *network.PublicIPAddressesClient
@@ -31,6 +32,9 @@ func NewClient(o *common.ClientOptions) *Client {
 	ConnectionMonitorsClient := network.NewConnectionMonitorsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&ConnectionMonitorsClient.Client, o.ResourceManagerAuthorizer)
 
+	DefaultSecurityRulesClient := network.NewDefaultSecurityRulesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&DefaultSecurityRulesClient.Client, o.ResourceManagerAuthorizer)
+
 	ExpressRouteAuthsClient := network.NewExpressRouteCircuitAuthorizationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&ExpressRouteAuthsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -87,6 +91,7 @@ func NewClient(o *common.ClientOptions) *Client {
 
 	return &Client{
 		ApplicationSecurityGroupsClient: &ApplicationSecurityGroupsClient,
+		DefaultSecurityRulesClient:      &DefaultSecurityRulesClient,
 		InterfacesClient:                &InterfacesClient,
 		LocalNetworkGatewaysClient:      &LocalNetworkGatewaysClient,
 		PublicIPsClient:                 &PublicIPsClient,
@@ -0,0 +1,359 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func networkInterfaceEffectiveRulesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: networkInterfaceEffectiveRulesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"network_interface_name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"effective_network_security_group": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"network_security_group_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"associated_network_interface_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"associated_subnet_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"security_rule": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Resource{
+								Schema: map[string]*pluginsdk.Schema{
+									"name": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"protocol": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"source_port_range": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"source_port_ranges": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+									},
+
+									"destination_port_range": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"destination_port_ranges": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+									},
+
+									"source_address_prefix": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"source_address_prefixes": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+									},
+
+									"expanded_source_address_prefixes": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+									},
+
+									"destination_address_prefix": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"destination_address_prefixes": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+									},
+
+									"expanded_destination_address_prefixes": {
+										Type:     pluginsdk.TypeList,
+										Computed: true,
+										Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+									},
+
+									"access": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+
+									"priority": {
+										Type:     pluginsdk.TypeInt,
+										Computed: true,
+									},
+
+									"direction": {
+										Type:     pluginsdk.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"effective_route": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"source": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"state": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"address_prefixes": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+
+						"next_hop_type": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"next_hop_ip_addresses": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func networkInterfaceEffectiveRulesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.InterfacesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewNetworkInterfaceID(subscriptionId, d.Get("resource_group_name").(string), d.Get("network_interface_name").(string))
+
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	// the effective rules & routes are only available once the Network Interface is attached to a running Virtual Machine
+	if props := resp.InterfacePropertiesFormat; props == nil || props.VirtualMachine == nil {
+		return fmt.Errorf("%s must be attached to a running Virtual Machine to retrieve the effective rules", id)
+	}
+
+	nsgFuture, err := client.ListEffectiveNetworkSecurityGroups(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("listing Effective Network Security Groups for %s: %+v", id, err)
+	}
+	if err = nsgFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the Effective Network Security Groups for %s: %+v", id, err)
+	}
+	nsgs, err := nsgFuture.Result(*client)
+	if err != nil {
+		return fmt.Errorf("retrieving Effective Network Security Groups for %s: %+v", id, err)
+	}
+
+	routeFuture, err := client.GetEffectiveRouteTable(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving Effective Route Table for %s: %+v", id, err)
+	}
+	if err = routeFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for the Effective Route Table for %s: %+v", id, err)
+	}
+	routes, err := routeFuture.Result(*client)
+	if err != nil {
+		return fmt.Errorf("retrieving Effective Route Table for %s: %+v", id, err)
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("network_interface_name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+
+	if err := d.Set("effective_network_security_group", flattenNetworkInterfaceEffectiveNetworkSecurityGroups(nsgs.Value)); err != nil {
+		return fmt.Errorf("setting `effective_network_security_group`: %+v", err)
+	}
+
+	if err := d.Set("effective_route", flattenNetworkInterfaceEffectiveRoutes(routes.Value)); err != nil {
+		return fmt.Errorf("setting `effective_route`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenNetworkInterfaceEffectiveNetworkSecurityGroups(input *[]network.EffectiveNetworkSecurityGroup) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		networkSecurityGroupId := ""
+		if item.NetworkSecurityGroup != nil && item.NetworkSecurityGroup.ID != nil {
+			networkSecurityGroupId = *item.NetworkSecurityGroup.ID
+		}
+
+		networkInterfaceId := ""
+		subnetId := ""
+		if association := item.Association; association != nil {
+			if association.NetworkInterface != nil && association.NetworkInterface.ID != nil {
+				networkInterfaceId = *association.NetworkInterface.ID
+			}
+			if association.Subnet != nil && association.Subnet.ID != nil {
+				subnetId = *association.Subnet.ID
+			}
+		}
+
+		results = append(results, map[string]interface{}{
+			"network_security_group_id":       networkSecurityGroupId,
+			"associated_network_interface_id": networkInterfaceId,
+			"associated_subnet_id":            subnetId,
+			"security_rule":                   flattenNetworkInterfaceEffectiveSecurityRules(item.EffectiveSecurityRules),
+		})
+	}
+
+	return results
+}
+
+func flattenNetworkInterfaceEffectiveSecurityRules(input *[]network.EffectiveNetworkSecurityRule) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, rule := range *input {
+		name := ""
+		if rule.Name != nil {
+			name = *rule.Name
+		}
+
+		sourcePortRange := ""
+		if rule.SourcePortRange != nil {
+			sourcePortRange = *rule.SourcePortRange
+		}
+
+		destinationPortRange := ""
+		if rule.DestinationPortRange != nil {
+			destinationPortRange = *rule.DestinationPortRange
+		}
+
+		sourceAddressPrefix := ""
+		if rule.SourceAddressPrefix != nil {
+			sourceAddressPrefix = *rule.SourceAddressPrefix
+		}
+
+		destinationAddressPrefix := ""
+		if rule.DestinationAddressPrefix != nil {
+			destinationAddressPrefix = *rule.DestinationAddressPrefix
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":                                  name,
+			"protocol":                              string(rule.Protocol),
+			"source_port_range":                     sourcePortRange,
+			"source_port_ranges":                    utils.FlattenStringSlice(rule.SourcePortRanges),
+			"destination_port_range":                destinationPortRange,
+			"destination_port_ranges":               utils.FlattenStringSlice(rule.DestinationPortRanges),
+			"source_address_prefix":                 sourceAddressPrefix,
+			"source_address_prefixes":               utils.FlattenStringSlice(rule.SourceAddressPrefixes),
+			"expanded_source_address_prefixes":      utils.FlattenStringSlice(rule.ExpandedSourceAddressPrefix),
+			"destination_address_prefix":            destinationAddressPrefix,
+			"destination_address_prefixes":          utils.FlattenStringSlice(rule.DestinationAddressPrefixes),
+			"expanded_destination_address_prefixes": utils.FlattenStringSlice(rule.ExpandedDestinationAddressPrefix),
+			"access":                                string(rule.Access),
+			"priority":                              int(utils.NormaliseNilableInt32(rule.Priority)),
+			"direction":                             string(rule.Direction),
+		})
+	}
+
+	return results
+}
+
+func flattenNetworkInterfaceEffectiveRoutes(input *[]network.EffectiveRoute) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, route := range *input {
+		name := ""
+		if route.Name != nil {
+			name = *route.Name
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":                  name,
+			"source":                string(route.Source),
+			"state":                 string(route.State),
+			"address_prefixes":      utils.FlattenStringSlice(route.AddressPrefix),
+			"next_hop_type":         string(route.NextHopType),
+			"next_hop_ip_addresses": utils.FlattenStringSlice(route.NextHopIPAddress),
+		})
+	}
+
+	return results
+}
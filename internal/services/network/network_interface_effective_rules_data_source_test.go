@@ -0,0 +1,98 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type NetworkInterfaceEffectiveRulesDataSource struct{}
+
+func TestAccDataSourceNetworkInterfaceEffectiveRules_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_network_interface_effective_rules", "test")
+	r := NetworkInterfaceEffectiveRulesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("effective_network_security_group.#").Exists(),
+				check.That(data.ResourceName).Key("effective_route.#").Exists(),
+				check.That(data.ResourceName).Key("effective_route.0.next_hop_type").Exists(),
+			),
+		},
+	})
+}
+
+func (NetworkInterfaceEffectiveRulesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctestvn-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurestack_network_interface" "test" {
+  name                = "acctestnic-%[1]d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  ip_configuration {
+    name                          = "internal"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurestack_linux_virtual_machine" "test" {
+  name                            = "acctestVM-%[1]d"
+  resource_group_name             = azurestack_resource_group.test.name
+  location                        = azurestack_resource_group.test.location
+  size                            = "Standard_F2"
+  admin_username                  = "adminuser"
+  admin_password                  = "P@$$w0rd1234!"
+  disable_password_authentication = false
+  network_interface_ids = [
+    azurestack_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+
+data "azurestack_network_interface_effective_rules" "test" {
+  network_interface_name = azurestack_network_interface.test.name
+  resource_group_name    = azurestack_resource_group.test.name
+
+  depends_on = [azurestack_linux_virtual_machine.test]
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
@@ -0,0 +1,217 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+)
+
+// privateIPAddressAllocationNextAvailable is a provider-side allocation method which picks the next free
+// address within the Subnet at create time and then pins it as a Static allocation
+const privateIPAddressAllocationNextAvailable = "NextAvailable"
+
+// azureReservedAddressesAtStartOfSubnet is the number of addresses Azure reserves at the start of each Subnet
+const azureReservedAddressesAtStartOfSubnet = 4
+
+func suppressPrivateIPAddressAllocationDiff(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	if strings.EqualFold(old, new) {
+		return true
+	}
+
+	// once allocated, `NextAvailable` addresses are pinned and returned from the API as `Static`
+	return strings.EqualFold(old, string(network.Static)) && strings.EqualFold(new, privateIPAddressAllocationNextAvailable)
+}
+
+// ipConfigurationsRequiringNextAvailableAddress returns the names of the IP Configurations using the
+// `NextAvailable` allocation method which don't yet have a Private IP Address pinned
+func ipConfigurationsRequiringNextAvailableAddress(input []interface{}) []string {
+	names := make([]string, 0)
+
+	for _, raw := range input {
+		data := raw.(map[string]interface{})
+		if !strings.EqualFold(data["private_ip_address_allocation"].(string), privateIPAddressAllocationNextAvailable) {
+			continue
+		}
+
+		if data["private_ip_address"].(string) != "" {
+			continue
+		}
+
+		names = append(names, data["name"].(string))
+	}
+
+	return names
+}
+
+// assignNextAvailablePrivateIPAddresses populates the Private IP Address for each of the named IP Configurations
+// with the next free address in its Subnet. The caller is expected to hold the Subnet and Virtual Network locks
+// (see `determineResourcesToLockFromIPConfiguration`) until the Network Interface has been created, so that
+// concurrent allocations within the same Subnet don't pick the same address.
+func assignNextAvailablePrivateIPAddresses(ctx context.Context, vnetClient *network.VirtualNetworksClient, subnetsClient *network.SubnetsClient, configs *[]network.InterfaceIPConfiguration, names []string) error {
+	if configs == nil || len(names) == 0 {
+		return nil
+	}
+
+	// track the addresses we've picked so multiple IP Configurations in the same Subnet don't collide
+	allocated := make(map[string]struct{})
+
+	for _, name := range names {
+		config := FindNetworkInterfaceIPConfiguration(configs, name)
+		if config == nil || config.InterfaceIPConfigurationPropertiesFormat == nil {
+			return fmt.Errorf("IP Configuration %q was not found", name)
+		}
+
+		props := config.InterfaceIPConfigurationPropertiesFormat
+		if props.Subnet == nil || props.Subnet.ID == nil {
+			return fmt.Errorf("a `subnet_id` must be specified for IP Configuration %q to use the %q allocation method", name, privateIPAddressAllocationNextAvailable)
+		}
+
+		subnetId, err := parse.SubnetID(*props.Subnet.ID)
+		if err != nil {
+			return err
+		}
+
+		subnet, err := subnetsClient.Get(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, "")
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", *subnetId, err)
+		}
+		if subnet.SubnetPropertiesFormat == nil || subnet.SubnetPropertiesFormat.AddressPrefix == nil {
+			return fmt.Errorf("retrieving %s: `properties.addressPrefix` was nil", *subnetId)
+		}
+
+		ipAddress, err := findNextAvailablePrivateIPAddress(ctx, vnetClient, *subnetId, *subnet.SubnetPropertiesFormat.AddressPrefix, allocated)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] Allocating Private IP Address %q from %s to IP Configuration %q", ipAddress, *subnetId, name)
+		allocated[ipAddress] = struct{}{}
+
+		props.PrivateIPAllocationMethod = network.Static
+		props.PrivateIPAddress = &ipAddress
+		*configs = *updateNetworkInterfaceIPConfiguration(*config, configs)
+	}
+
+	return nil
+}
+
+// findNextAvailablePrivateIPAddress returns the lowest free address within the specified Subnet, skipping any
+// addresses which have already been handed out but aren't yet in use
+func findNextAvailablePrivateIPAddress(ctx context.Context, client *network.VirtualNetworksClient, subnetId parse.SubnetId, addressPrefix string, exclude map[string]struct{}) (string, error) {
+	_, ipNet, err := net.ParseCIDR(addressPrefix)
+	if err != nil {
+		return "", fmt.Errorf("parsing the Address Prefix %q for %s: %+v", addressPrefix, subnetId, err)
+	}
+
+	first, last, err := usablePrivateIPAddressRange(ipNet)
+	if err != nil {
+		return "", fmt.Errorf("determining the usable addresses for %s: %+v", subnetId, err)
+	}
+
+	for candidate := first; bytes.Compare(candidate, last) <= 0; candidate = nextIPAddress(candidate) {
+		if _, ok := exclude[candidate.String()]; ok {
+			continue
+		}
+
+		resp, err := client.CheckIPAddressAvailability(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, candidate.String())
+		if err != nil {
+			return "", fmt.Errorf("checking the availability of %q in %s: %+v", candidate.String(), subnetId, err)
+		}
+
+		if resp.Available != nil && *resp.Available {
+			return candidate.String(), nil
+		}
+
+		// when the address is in use the API suggests a handful of nearby free addresses
+		if resp.AvailableIPAddresses == nil {
+			continue
+		}
+		var highest net.IP
+		for _, v := range *resp.AvailableIPAddresses {
+			suggested := net.ParseIP(v).To4()
+			if suggested == nil || !ipNet.Contains(suggested) {
+				continue
+			}
+			if bytes.Compare(suggested, first) < 0 || bytes.Compare(suggested, last) > 0 {
+				continue
+			}
+			if _, ok := exclude[suggested.String()]; !ok {
+				return suggested.String(), nil
+			}
+			if highest == nil || bytes.Compare(suggested, highest) > 0 {
+				highest = suggested
+			}
+		}
+
+		// every suggestion has already been handed out, so skip past them
+		if highest != nil && bytes.Compare(highest, candidate) > 0 {
+			candidate = highest
+		}
+	}
+
+	return "", fmt.Errorf("no free Private IP Addresses were found in %s (%q)", subnetId, addressPrefix)
+}
+
+// usablePrivateIPAddressRange returns the first and last addresses which can be assigned within an IPv4 network,
+// taking into account the addresses Azure reserves at the start and end of each Subnet
+func usablePrivateIPAddressRange(ipNet *net.IPNet) (net.IP, net.IP, error) {
+	networkAddress := ipNet.IP.To4()
+	if networkAddress == nil {
+		return nil, nil, fmt.Errorf("only IPv4 Address Prefixes are supported")
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if bits-ones < 3 {
+		return nil, nil, fmt.Errorf("the Address Prefix %q is too small to contain any usable addresses", ipNet.String())
+	}
+
+	first := make(net.IP, len(networkAddress))
+	copy(first, networkAddress)
+	for i := 0; i < azureReservedAddressesAtStartOfSubnet; i++ {
+		first = nextIPAddress(first)
+	}
+
+	// the last address in the range is the broadcast address, which is also reserved
+	broadcast := make(net.IP, len(networkAddress))
+	for i := range networkAddress {
+		broadcast[i] = networkAddress[i] | ^ipNet.Mask[i]
+	}
+	last := previousIPAddress(broadcast)
+
+	return first, last, nil
+}
+
+func nextIPAddress(input net.IP) net.IP {
+	output := make(net.IP, len(input))
+	copy(output, input)
+
+	for i := len(output) - 1; i >= 0; i-- {
+		output[i]++
+		if output[i] != 0 {
+			break
+		}
+	}
+
+	return output
+}
+
+func previousIPAddress(input net.IP) net.IP {
+	output := make(net.IP, len(input))
+	copy(output, input)
+
+	for i := len(output) - 1; i >= 0; i-- {
+		output[i]--
+		if output[i] != 255 {
+			break
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,106 @@
+package network
+
+import (
+	"net"
+	"testing"
+)
+
+func TestUsablePrivateIPAddressRange(t *testing.T) {
+	testData := []struct {
+		input string
+		first string
+		last  string
+		error bool
+	}{
+		{
+			input: "10.0.2.0/24",
+			first: "10.0.2.4",
+			last:  "10.0.2.254",
+		},
+		{
+			input: "10.0.0.0/16",
+			first: "10.0.0.4",
+			last:  "10.0.255.254",
+		},
+		{
+			input: "192.168.1.8/29",
+			first: "192.168.1.12",
+			last:  "192.168.1.14",
+		},
+		{
+			input: "192.168.1.8/30",
+			error: true,
+		},
+		{
+			input: "fd00::/64",
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		_, ipNet, err := net.ParseCIDR(v.input)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.input, err)
+		}
+
+		first, last, err := usablePrivateIPAddressRange(ipNet)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("expected no error for %q but got: %+v", v.input, err)
+		}
+
+		if v.error {
+			t.Fatalf("expected an error for %q but didn't get one", v.input)
+		}
+
+		if first.String() != v.first {
+			t.Fatalf("expected the first address for %q to be %q but got %q", v.input, v.first, first.String())
+		}
+
+		if last.String() != v.last {
+			t.Fatalf("expected the last address for %q to be %q but got %q", v.input, v.last, last.String())
+		}
+	}
+}
+
+func TestNextAndPreviousIPAddress(t *testing.T) {
+	testData := []struct {
+		input    string
+		next     string
+		previous string
+	}{
+		{
+			input:    "10.0.0.4",
+			next:     "10.0.0.5",
+			previous: "10.0.0.3",
+		},
+		{
+			input:    "10.0.0.255",
+			next:     "10.0.1.0",
+			previous: "10.0.0.254",
+		},
+		{
+			input:    "10.0.1.0",
+			next:     "10.0.1.1",
+			previous: "10.0.0.255",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		input := net.ParseIP(v.input).To4()
+		if actual := nextIPAddress(input).String(); actual != v.next {
+			t.Fatalf("expected the next address after %q to be %q but got %q", v.input, v.next, actual)
+		}
+
+		if actual := previousIPAddress(input).String(); actual != v.previous {
+			t.Fatalf("expected the previous address before %q to be %q but got %q", v.input, v.previous, actual)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package network
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
@@ -93,9 +94,10 @@ func networkInterface() *pluginsdk.Resource {
 							ValidateFunc: validation.StringInSlice([]string{
 								string(network.Dynamic),
 								string(network.Static),
+								privateIPAddressAllocationNextAvailable,
 							}, true),
 							StateFunc:        state.IgnoreCase,
-							DiffSuppressFunc: suppress.CaseDifference,
+							DiffSuppressFunc: suppressPrivateIPAddressAllocationDiff,
 						},
 
 						"public_ip_address_id": {
@@ -173,6 +175,8 @@ func networkInterface() *pluginsdk.Resource {
 
 func networkInterfaceCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Network.InterfacesClient
+	subnetsClient := meta.(*clients.Client).Network.SubnetsClient
+	vnetClient := meta.(*clients.Client).Network.VnetClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -228,6 +232,10 @@ func networkInterfaceCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	lockingDetails.lock()
 	defer lockingDetails.unlock()
 
+	if err := assignNextAvailablePrivateIPAddresses(ctx, vnetClient, subnetsClient, ipConfigs, ipConfigurationsRequiringNextAvailableAddress(ipConfigsRaw)); err != nil {
+		return fmt.Errorf("allocating Private IP Addresses for %s: %+v", id, err)
+	}
+
 	if len(*ipConfigs) > 0 {
 		properties.IPConfigurations = ipConfigs
 	}
@@ -254,6 +262,8 @@ func networkInterfaceCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 func networkInterfaceUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Network.InterfacesClient
+	subnetsClient := meta.(*clients.Client).Network.SubnetsClient
+	vnetClient := meta.(*clients.Client).Network.VnetClient
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -316,6 +326,10 @@ func networkInterfaceUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		lockingDetails.lock()
 		defer lockingDetails.unlock()
 
+		if err := assignNextAvailablePrivateIPAddresses(ctx, vnetClient, subnetsClient, ipConfigs, ipConfigurationsRequiringNextAvailableAddress(ipConfigsRaw)); err != nil {
+			return fmt.Errorf("allocating Private IP Addresses for %s: %+v", *id, err)
+		}
+
 		// then map the fields managed in other resources back
 		ipConfigs = mapFieldsToNetworkInterface(ipConfigs, info)
 
@@ -491,6 +505,10 @@ func expandNetworkInterfaceIPConfigurations(input []interface{}) (*[]network.Int
 		privateIpAddressVersion := network.IPVersion(data["private_ip_address_version"].(string))
 
 		allocationMethod := network.IPAllocationMethod(privateIpAllocationMethod)
+		if strings.EqualFold(privateIpAllocationMethod, privateIPAddressAllocationNextAvailable) {
+			// the address itself is allocated once the Subnet has been locked
+			allocationMethod = network.Static
+		}
 		properties := network.InterfaceIPConfigurationPropertiesFormat{
 			PrivateIPAllocationMethod: allocationMethod,
 			PrivateIPAddressVersion:   privateIpAddressVersion,
@@ -180,6 +180,22 @@ func TestAccNetworkInterface_static(t *testing.T) {
 	})
 }
 
+func TestAccNetworkInterface_nextAvailable(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurestack_network_interface", "test")
+	r := NetworkInterfaceResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.nextAvailable(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip_configuration.0.private_ip_address").HasValue("10.0.2.4"),
+				check.That("azurestack_network_interface.second").Key("ip_configuration.0.private_ip_address").HasValue("10.0.2.5"),
+			),
+		},
+		data.ImportStep("ip_configuration.0.private_ip_address_allocation"),
+	})
+}
+
 func TestAccNetworkInterface_tags(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurestack_network_interface", "test")
 	r := NetworkInterfaceResource{}
@@ -551,6 +567,38 @@ resource "azurestack_network_interface" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func (r NetworkInterfaceResource) nextAvailable(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurestack_network_interface" "test" {
+  name                = "acctestni-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  ip_configuration {
+    name                          = "primary"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "NextAvailable"
+  }
+}
+
+resource "azurestack_network_interface" "second" {
+  name                = "acctestni2-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  ip_configuration {
+    name                          = "primary"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "NextAvailable"
+  }
+
+  depends_on = [azurestack_network_interface.test]
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
+
 func (r NetworkInterfaceResource) tags(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
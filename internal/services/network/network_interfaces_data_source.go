@@ -0,0 +1,190 @@
+package network
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func networkInterfacesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: networkInterfacesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"resource_group_name": commonschema.ResourceGroupNameOptional(),
+
+			"name_prefix": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"required_tags": tags.Schema(),
+
+			"attachment_status": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Attached",
+					"Unattached",
+				}, false),
+			},
+
+			"network_interfaces": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"location": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"mac_address": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"private_ip_address": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"virtual_machine_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"tags": tags.SchemaDataSource(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func networkInterfacesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.InterfacesClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	var iterator network.InterfaceListResultIterator
+	var err error
+	if resourceGroup != "" {
+		log.Printf("[DEBUG] Reading Network Interfaces in Resource Group %q", resourceGroup)
+		iterator, err = client.ListComplete(ctx, resourceGroup)
+	} else {
+		log.Printf("[DEBUG] Reading Network Interfaces in the Subscription")
+		iterator, err = client.ListAllComplete(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("listing Network Interfaces: %+v", err)
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	requiredTags := d.Get("required_tags").(map[string]interface{})
+	attachmentStatus := d.Get("attachment_status").(string)
+
+	results := make([]interface{}, 0)
+	for iterator.NotDone() {
+		element := iterator.Value()
+
+		isAttached := element.InterfacePropertiesFormat != nil && element.InterfacePropertiesFormat.VirtualMachine != nil
+
+		include := true
+		if namePrefix != "" && (element.Name == nil || !strings.HasPrefix(*element.Name, namePrefix)) {
+			include = false
+		}
+		if !tags.MatchesAll(element.Tags, requiredTags) {
+			include = false
+		}
+		if attachmentStatus == "Attached" && !isAttached {
+			include = false
+		}
+		if attachmentStatus == "Unattached" && isAttached {
+			include = false
+		}
+
+		if include {
+			results = append(results, flattenDataSourceNetworkInterface(element))
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("enumerating Network Interfaces: %+v", err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("network_interfaces", results); err != nil {
+		return fmt.Errorf("setting `network_interfaces`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenDataSourceNetworkInterface(input network.Interface) map[string]interface{} {
+	id := ""
+	if input.ID != nil {
+		id = *input.ID
+	}
+
+	name := ""
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	macAddress := ""
+	privateIPAddress := ""
+	virtualMachineId := ""
+	if props := input.InterfacePropertiesFormat; props != nil {
+		if props.MacAddress != nil {
+			macAddress = *props.MacAddress
+		}
+
+		if props.VirtualMachine != nil && props.VirtualMachine.ID != nil {
+			virtualMachineId = *props.VirtualMachine.ID
+		}
+
+		if configs := props.IPConfigurations; configs != nil {
+			for _, config := range *configs {
+				if config.InterfaceIPConfigurationPropertiesFormat == nil || config.InterfaceIPConfigurationPropertiesFormat.PrivateIPAddress == nil {
+					continue
+				}
+
+				if primary := config.InterfaceIPConfigurationPropertiesFormat.Primary; privateIPAddress == "" || (primary != nil && *primary) {
+					privateIPAddress = *config.InterfaceIPConfigurationPropertiesFormat.PrivateIPAddress
+				}
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                 id,
+		"name":               name,
+		"location":           location.NormalizeNilable(input.Location),
+		"mac_address":        macAddress,
+		"private_ip_address": privateIPAddress,
+		"virtual_machine_id": virtualMachineId,
+		"tags":               tags.Flatten(input.Tags),
+	}
+}
@@ -0,0 +1,122 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type NetworkInterfacesDataSource struct{}
+
+func TestAccNetworkInterfacesDataSource_namePrefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_network_interfaces", "test")
+	r := NetworkInterfacesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.namePrefix(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("network_interfaces.#").HasValue("2"),
+				check.That(data.ResourceName).Key("network_interfaces.0.name").HasValue(fmt.Sprintf("acctestnica%d-0", data.RandomInteger)),
+				check.That(data.ResourceName).Key("network_interfaces.0.private_ip_address").Exists(),
+				check.That(data.ResourceName).Key("network_interfaces.0.virtual_machine_id").HasValue(""),
+			),
+		},
+	})
+}
+
+func TestAccNetworkInterfacesDataSource_attachmentStatus(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_network_interfaces", "test")
+	r := NetworkInterfacesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.attached(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("network_interfaces.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func (NetworkInterfacesDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctestvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurestack_network_interface" "test" {
+  count               = 2
+  name                = "acctestnica%d-${count.index}"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  ip_configuration {
+    name                          = "primary"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurestack_network_interface" "test2" {
+  name                = "acctestnicb%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  ip_configuration {
+    name                          = "primary"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (r NetworkInterfacesDataSource) namePrefix(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_network_interfaces" "test" {
+  resource_group_name = azurestack_resource_group.test.name
+  name_prefix         = "acctestnica"
+}
+`, r.template(data))
+}
+
+func (r NetworkInterfacesDataSource) attached(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_network_interfaces" "test" {
+  resource_group_name = azurestack_resource_group.test.name
+  attachment_status   = "Attached"
+}
+`, r.template(data))
+}
@@ -0,0 +1,156 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func networkSecurityGroupDefaultRulesDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: networkSecurityGroupDefaultRulesDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"network_security_group_name": {
+				Type:     pluginsdk.TypeString,
+				Required: true,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"default_security_rule": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"description": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"protocol": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"source_port_range": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"source_port_ranges": {
+							Type:     pluginsdk.TypeSet,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+							Set:      pluginsdk.HashString,
+						},
+
+						"destination_port_range": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"destination_port_ranges": {
+							Type:     pluginsdk.TypeSet,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+							Set:      pluginsdk.HashString,
+						},
+
+						"source_address_prefix": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"source_address_prefixes": {
+							Type:     pluginsdk.TypeSet,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+							Set:      pluginsdk.HashString,
+						},
+
+						"destination_address_prefix": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"destination_address_prefixes": {
+							Type:     pluginsdk.TypeSet,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+							Set:      pluginsdk.HashString,
+						},
+
+						"access": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"priority": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"direction": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func networkSecurityGroupDefaultRulesDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.DefaultSecurityRulesClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewNetworkSecurityGroupID(subscriptionId, d.Get("resource_group_name").(string), d.Get("network_security_group_name").(string))
+
+	iterator, err := client.ListComplete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(iterator.Response().Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("listing Default Security Rules for %s: %+v", id, err)
+	}
+
+	rules := make([]network.SecurityRule, 0)
+	for iterator.NotDone() {
+		rules = append(rules, iterator.Value())
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("enumerating Default Security Rules for %s: %+v", id, err)
+		}
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("network_security_group_name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+
+	if err := d.Set("default_security_rule", flattenNetworkSecurityRules(&rules)); err != nil {
+		return fmt.Errorf("setting `default_security_rule`: %+v", err)
+	}
+
+	return nil
+}
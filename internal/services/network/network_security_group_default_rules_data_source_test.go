@@ -0,0 +1,51 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type NetworkSecurityGroupDefaultRulesDataSource struct{}
+
+func TestAccDataSourceNetworkSecurityGroupDefaultRules_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_network_security_group_default_rules", "test")
+	r := NetworkSecurityGroupDefaultRulesDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("default_security_rule.#").HasValue("6"),
+				check.That(data.ResourceName).Key("default_security_rule.0.name").Exists(),
+				check.That(data.ResourceName).Key("default_security_rule.0.priority").Exists(),
+			),
+		},
+	})
+}
+
+func (NetworkSecurityGroupDefaultRulesDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_network_security_group" "test" {
+  name                = "acctestnsg-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+data "azurestack_network_security_group_default_rules" "test" {
+  network_security_group_name = azurestack_network_security_group.test.name
+  resource_group_name         = azurestack_resource_group.test.name
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
@@ -0,0 +1,177 @@
+package network
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+)
+
+func networkSecurityGroupsDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: networkSecurityGroupsDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"resource_group_name": commonschema.ResourceGroupNameOptional(),
+
+			"name_prefix": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"required_tags": tags.Schema(),
+
+			"attachment_status": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Attached",
+					"Unattached",
+				}, false),
+			},
+
+			"network_security_groups": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"location": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"network_interface_ids": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+						"subnet_ids": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+						"tags": tags.SchemaDataSource(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func networkSecurityGroupsDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.SecurityGroupClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	var iterator network.SecurityGroupListResultIterator
+	var err error
+	if resourceGroup != "" {
+		log.Printf("[DEBUG] Reading Network Security Groups in Resource Group %q", resourceGroup)
+		iterator, err = client.ListComplete(ctx, resourceGroup)
+	} else {
+		log.Printf("[DEBUG] Reading Network Security Groups in the Subscription")
+		iterator, err = client.ListAllComplete(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("listing Network Security Groups: %+v", err)
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	requiredTags := d.Get("required_tags").(map[string]interface{})
+	attachmentStatus := d.Get("attachment_status").(string)
+
+	results := make([]interface{}, 0)
+	for iterator.NotDone() {
+		element := iterator.Value()
+
+		networkInterfaceIds := make([]interface{}, 0)
+		subnetIds := make([]interface{}, 0)
+		if props := element.SecurityGroupPropertiesFormat; props != nil {
+			if props.NetworkInterfaces != nil {
+				for _, nic := range *props.NetworkInterfaces {
+					if nic.ID != nil {
+						networkInterfaceIds = append(networkInterfaceIds, *nic.ID)
+					}
+				}
+			}
+
+			if props.Subnets != nil {
+				for _, subnet := range *props.Subnets {
+					if subnet.ID != nil {
+						subnetIds = append(subnetIds, *subnet.ID)
+					}
+				}
+			}
+		}
+		isAttached := len(networkInterfaceIds) > 0 || len(subnetIds) > 0
+
+		include := true
+		if namePrefix != "" && (element.Name == nil || !strings.HasPrefix(*element.Name, namePrefix)) {
+			include = false
+		}
+		if !tags.MatchesAll(element.Tags, requiredTags) {
+			include = false
+		}
+		if attachmentStatus == "Attached" && !isAttached {
+			include = false
+		}
+		if attachmentStatus == "Unattached" && isAttached {
+			include = false
+		}
+
+		if include {
+			id := ""
+			if element.ID != nil {
+				id = *element.ID
+			}
+
+			name := ""
+			if element.Name != nil {
+				name = *element.Name
+			}
+
+			results = append(results, map[string]interface{}{
+				"id":                    id,
+				"name":                  name,
+				"location":              location.NormalizeNilable(element.Location),
+				"network_interface_ids": networkInterfaceIds,
+				"subnet_ids":            subnetIds,
+				"tags":                  tags.Flatten(element.Tags),
+			})
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("enumerating Network Security Groups: %+v", err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("network_security_groups", results); err != nil {
+		return fmt.Errorf("setting `network_security_groups`: %+v", err)
+	}
+
+	return nil
+}
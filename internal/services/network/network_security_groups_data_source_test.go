@@ -0,0 +1,96 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type NetworkSecurityGroupsDataSource struct{}
+
+func TestAccNetworkSecurityGroupsDataSource_namePrefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_network_security_groups", "test")
+	r := NetworkSecurityGroupsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.namePrefix(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("network_security_groups.#").HasValue("2"),
+				check.That(data.ResourceName).Key("network_security_groups.0.name").HasValue(fmt.Sprintf("acctestnsga%d-0", data.RandomInteger)),
+			),
+		},
+	})
+}
+
+func TestAccNetworkSecurityGroupsDataSource_attachmentStatus(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_network_security_groups", "test")
+	r := NetworkSecurityGroupsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.unattached(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("network_security_groups.#").HasValue("3"),
+				check.That(data.ResourceName).Key("network_security_groups.0.network_interface_ids.#").HasValue("0"),
+				check.That(data.ResourceName).Key("network_security_groups.0.subnet_ids.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func (NetworkSecurityGroupsDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_network_security_group" "test" {
+  count               = 2
+  name                = "acctestnsga%d-${count.index}"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+
+resource "azurestack_network_security_group" "test2" {
+  name                = "acctestnsgb%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
+func (r NetworkSecurityGroupsDataSource) namePrefix(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_network_security_groups" "test" {
+  resource_group_name = azurestack_resource_group.test.name
+  name_prefix         = "acctestnsga"
+}
+`, r.template(data))
+}
+
+func (r NetworkSecurityGroupsDataSource) unattached(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_network_security_groups" "test" {
+  resource_group_name = azurestack_resource_group.test.name
+  attachment_status   = "Unattached"
+}
+`, r.template(data))
+}
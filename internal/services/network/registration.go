@@ -21,16 +21,22 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurestack_network_interface":                  networkInterfaceDataSource(),
-		"azurestack_public_ip":                          publicIPDataSource(),
-		"azurestack_public_ips":                         publicIPsDataSource(),
-		"azurestack_route_table":                        routeTableDataSource(),
-		"azurestack_subnet":                             subnetDataSource(),
-		"azurestack_virtual_network":                    virtualNetworkDataSource(),
-		"azurestack_network_security_group":             networkSecurityGroupDataSource(),
-		"azurestack_virtual_network_gateway":            virtualNetworkGatewayDataSource(),
-		"azurestack_virtual_network_gateway_connection": virtualNetworkGatewayConnectionDataSource(),
-		"azurestack_local_network_gateway":              localNetworkGatewayDataSource(),
+		"azurestack_network_interface":                    networkInterfaceDataSource(),
+		"azurestack_network_interface_effective_rules":    networkInterfaceEffectiveRulesDataSource(),
+		"azurestack_network_interfaces":                   networkInterfacesDataSource(),
+		"azurestack_public_ip":                            publicIPDataSource(),
+		"azurestack_public_ips":                           publicIPsDataSource(),
+		"azurestack_route_table":                          routeTableDataSource(),
+		"azurestack_subnet":                               subnetDataSource(),
+		"azurestack_virtual_network":                      virtualNetworkDataSource(),
+		"azurestack_virtual_network_ip_availability":      virtualNetworkIPAvailabilityDataSource(),
+		"azurestack_virtual_networks":                     virtualNetworksDataSource(),
+		"azurestack_network_security_group":               networkSecurityGroupDataSource(),
+		"azurestack_network_security_group_default_rules": networkSecurityGroupDefaultRulesDataSource(),
+		"azurestack_network_security_groups":              networkSecurityGroupsDataSource(),
+		"azurestack_virtual_network_gateway":              virtualNetworkGatewayDataSource(),
+		"azurestack_virtual_network_gateway_connection":   virtualNetworkGatewayConnectionDataSource(),
+		"azurestack_local_network_gateway":                localNetworkGatewayDataSource(),
 	}
 }
 
@@ -1,7 +1,9 @@
 package network
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
@@ -70,6 +72,110 @@ func virtualNetworkDataSource() *pluginsdk.Resource {
 					Type: pluginsdk.TypeString,
 				},
 			},
+
+			"subnet": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"address_prefix": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"network_security_group_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"route_table_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"ip_configuration_count": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"ip_addresses_used": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"ip_addresses_limit": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"vnet_peering": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"remote_virtual_network_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"remote_address_space": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+
+						"peering_state": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"allow_virtual_network_access": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"allow_forwarded_traffic": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"allow_gateway_transit": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+
+						"use_remote_gateways": {
+							Type:     pluginsdk.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -90,6 +196,11 @@ func virtualNetworkDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("retrieving %s: %+v", id, err)
 	}
 
+	usages, err := listVirtualNetworkSubnetUsage(ctx, client, id)
+	if err != nil {
+		return err
+	}
+
 	d.SetId(id.ID()) // TODO before release confirm no state migration is required for this
 	d.Set("location", location.NormalizeNilable(resp.Location))
 
@@ -115,6 +226,14 @@ func virtualNetworkDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) e
 		if err := d.Set("vnet_peerings", flattenVnetPeerings(props.VirtualNetworkPeerings)); err != nil {
 			return fmt.Errorf("setting `vnet_peerings`: %v", err)
 		}
+
+		if err := d.Set("subnet", flattenVnetSubnetDetails(props.Subnets, usages)); err != nil {
+			return fmt.Errorf("setting `subnet`: %v", err)
+		}
+
+		if err := d.Set("vnet_peering", flattenVnetPeeringDetails(props.VirtualNetworkPeerings)); err != nil {
+			return fmt.Errorf("setting `vnet_peering`: %v", err)
+		}
 	}
 	return nil
 }
@@ -150,3 +269,143 @@ func flattenVnetPeerings(input *[]network.VirtualNetworkPeering) map[string]inte
 
 	return output
 }
+
+// listVirtualNetworkSubnetUsage returns the IP Address usage for each Subnet within the Virtual Network, keyed by
+// the lower-cased Subnet ID since the casing returned from the usage API doesn't always match the Subnet
+func listVirtualNetworkSubnetUsage(ctx context.Context, client *network.VirtualNetworksClient, id parse.VirtualNetworkId) (map[string]network.VirtualNetworkUsage, error) {
+	usages := make(map[string]network.VirtualNetworkUsage)
+
+	iterator, err := client.ListUsageComplete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return nil, fmt.Errorf("listing usage for %s: %+v", id, err)
+	}
+
+	for iterator.NotDone() {
+		usage := iterator.Value()
+		if usage.ID != nil {
+			usages[strings.ToLower(*usage.ID)] = usage
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("enumerating usage for %s: %+v", id, err)
+		}
+	}
+
+	return usages, nil
+}
+
+func flattenVnetSubnetDetails(input *[]network.Subnet, usages map[string]network.VirtualNetworkUsage) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, subnet := range *input {
+		name := ""
+		if subnet.Name != nil {
+			name = *subnet.Name
+		}
+
+		id := ""
+		if subnet.ID != nil {
+			id = *subnet.ID
+		}
+
+		addressPrefix := ""
+		networkSecurityGroupId := ""
+		routeTableId := ""
+		ipConfigurationCount := 0
+		if props := subnet.SubnetPropertiesFormat; props != nil {
+			if props.AddressPrefix != nil {
+				addressPrefix = *props.AddressPrefix
+			}
+
+			if props.NetworkSecurityGroup != nil && props.NetworkSecurityGroup.ID != nil {
+				networkSecurityGroupId = *props.NetworkSecurityGroup.ID
+			}
+
+			if props.RouteTable != nil && props.RouteTable.ID != nil {
+				routeTableId = *props.RouteTable.ID
+			}
+
+			if props.IPConfigurations != nil {
+				ipConfigurationCount = len(*props.IPConfigurations)
+			}
+		}
+
+		ipAddressesUsed := 0
+		ipAddressesLimit := 0
+		if usage, ok := usages[strings.ToLower(id)]; ok {
+			if usage.CurrentValue != nil {
+				ipAddressesUsed = int(*usage.CurrentValue)
+			}
+			if usage.Limit != nil {
+				ipAddressesLimit = int(*usage.Limit)
+			}
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":                      name,
+			"id":                        id,
+			"address_prefix":            addressPrefix,
+			"network_security_group_id": networkSecurityGroupId,
+			"route_table_id":            routeTableId,
+			"ip_configuration_count":    ipConfigurationCount,
+			"ip_addresses_used":         ipAddressesUsed,
+			"ip_addresses_limit":        ipAddressesLimit,
+		})
+	}
+
+	return results
+}
+
+func flattenVnetPeeringDetails(input *[]network.VirtualNetworkPeering) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, peering := range *input {
+		name := ""
+		if peering.Name != nil {
+			name = *peering.Name
+		}
+
+		id := ""
+		if peering.ID != nil {
+			id = *peering.ID
+		}
+
+		result := map[string]interface{}{
+			"name":                         name,
+			"id":                           id,
+			"remote_virtual_network_id":    "",
+			"remote_address_space":         make([]interface{}, 0),
+			"peering_state":                "",
+			"allow_virtual_network_access": false,
+			"allow_forwarded_traffic":      false,
+			"allow_gateway_transit":        false,
+			"use_remote_gateways":          false,
+		}
+
+		if props := peering.VirtualNetworkPeeringPropertiesFormat; props != nil {
+			if props.RemoteVirtualNetwork != nil && props.RemoteVirtualNetwork.ID != nil {
+				result["remote_virtual_network_id"] = *props.RemoteVirtualNetwork.ID
+			}
+
+			if props.RemoteAddressSpace != nil {
+				result["remote_address_space"] = utils.FlattenStringSlice(props.RemoteAddressSpace.AddressPrefixes)
+			}
+
+			result["peering_state"] = string(props.PeeringState)
+			result["allow_virtual_network_access"] = utils.NormaliseNilableBool(props.AllowVirtualNetworkAccess)
+			result["allow_forwarded_traffic"] = utils.NormaliseNilableBool(props.AllowForwardedTraffic)
+			result["allow_gateway_transit"] = utils.NormaliseNilableBool(props.AllowGatewayTransit)
+			result["use_remote_gateways"] = utils.NormaliseNilableBool(props.UseRemoteGateways)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -27,6 +27,10 @@ func TestAccVirtualNetworkDataSource_basic(t *testing.T) {
 				check.That(data.ResourceName).Key("dns_servers.0").HasValue("10.0.0.4"),
 				check.That(data.ResourceName).Key("address_space.0").HasValue("10.0.0.0/16"),
 				check.That(data.ResourceName).Key("subnets.0").HasValue("subnet1"),
+				check.That(data.ResourceName).Key("subnet.#").HasValue("1"),
+				check.That(data.ResourceName).Key("subnet.0.name").HasValue("subnet1"),
+				check.That(data.ResourceName).Key("subnet.0.address_prefix").HasValue("10.0.1.0/24"),
+				check.That(data.ResourceName).Key("subnet.0.ip_addresses_limit").Exists(),
 			),
 		},
 	})
@@ -79,6 +83,9 @@ func TestAccVirtualNetworkDataSource_peering(t *testing.T) {
 				check.That(data.ResourceName).Key("name").HasValue(virtualNetworkName),
 				check.That(data.ResourceName).Key("address_space.0").HasValue("10.0.1.0/24"),
 				check.That(data.ResourceName).Key("vnet_peerings.%").HasValue("1"),
+				check.That(data.ResourceName).Key("vnet_peering.#").HasValue("1"),
+				check.That(data.ResourceName).Key("vnet_peering.0.peering_state").Exists(),
+				check.That(data.ResourceName).Key("vnet_peering.0.remote_virtual_network_id").Exists(),
 			),
 		},
 	})
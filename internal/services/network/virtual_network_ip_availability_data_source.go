@@ -0,0 +1,189 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/network/parse"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualNetworkIPAvailabilityDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualNetworkIPAvailabilityDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"virtual_network_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"resource_group_name": commonschema.ResourceGroupNameForDataSource(),
+
+			"ip_address": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				AtLeastOneOf: []string{"ip_address", "subnet_name"},
+			},
+
+			"subnet_name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				AtLeastOneOf: []string{"ip_address", "subnet_name"},
+			},
+
+			"available": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"available_ip_addresses": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"next_available_ip_address": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"subnet_usage": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"subnet_id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+
+						"current_value": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+
+						"limit": {
+							Type:     pluginsdk.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func virtualNetworkIPAvailabilityDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.VnetClient
+	subnetsClient := meta.(*clients.Client).Network.SubnetsClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := parse.NewVirtualNetworkID(subscriptionId, d.Get("resource_group_name").(string), d.Get("virtual_network_name").(string))
+
+	available := false
+	availableIPAddresses := make([]interface{}, 0)
+	if ipAddress := d.Get("ip_address").(string); ipAddress != "" {
+		resp, err := client.CheckIPAddressAvailability(ctx, id.ResourceGroup, id.Name, ipAddress)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("%s was not found", id)
+			}
+			return fmt.Errorf("checking the availability of %q in %s: %+v", ipAddress, id, err)
+		}
+
+		available = resp.Available != nil && *resp.Available
+		availableIPAddresses = utils.FlattenStringSlice(resp.AvailableIPAddresses)
+	}
+
+	nextAvailableIPAddress := ""
+	if subnetName := d.Get("subnet_name").(string); subnetName != "" {
+		subnetId := parse.NewSubnetID(id.SubscriptionId, id.ResourceGroup, id.Name, subnetName)
+		subnet, err := subnetsClient.Get(ctx, subnetId.ResourceGroup, subnetId.VirtualNetworkName, subnetId.Name, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(subnet.Response) {
+				return fmt.Errorf("%s was not found", subnetId)
+			}
+			return fmt.Errorf("retrieving %s: %+v", subnetId, err)
+		}
+		if subnet.SubnetPropertiesFormat == nil || subnet.SubnetPropertiesFormat.AddressPrefix == nil {
+			return fmt.Errorf("retrieving %s: `properties.addressPrefix` was nil", subnetId)
+		}
+
+		nextAvailableIPAddress, err = findNextAvailablePrivateIPAddress(ctx, client, subnetId, *subnet.SubnetPropertiesFormat.AddressPrefix, map[string]struct{}{})
+		if err != nil {
+			return err
+		}
+	}
+
+	usages, err := client.ListUsageComplete(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(usages.Response().Response) {
+			return fmt.Errorf("%s was not found", id)
+		}
+		return fmt.Errorf("listing usage for %s: %+v", id, err)
+	}
+
+	subnetUsage := make([]interface{}, 0)
+	for usages.NotDone() {
+		usage := usages.Value()
+
+		subnetId := ""
+		if usage.ID != nil {
+			subnetId = *usage.ID
+		}
+
+		currentValue := 0
+		if usage.CurrentValue != nil {
+			currentValue = int(*usage.CurrentValue)
+		}
+
+		limit := 0
+		if usage.Limit != nil {
+			limit = int(*usage.Limit)
+		}
+
+		subnetUsage = append(subnetUsage, map[string]interface{}{
+			"subnet_id":     subnetId,
+			"current_value": currentValue,
+			"limit":         limit,
+		})
+
+		if err := usages.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("enumerating usage for %s: %+v", id, err)
+		}
+	}
+
+	d.SetId(id.ID())
+
+	d.Set("virtual_network_name", id.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+	d.Set("available", available)
+	d.Set("next_available_ip_address", nextAvailableIPAddress)
+
+	if err := d.Set("available_ip_addresses", availableIPAddresses); err != nil {
+		return fmt.Errorf("setting `available_ip_addresses`: %+v", err)
+	}
+
+	if err := d.Set("subnet_usage", subnetUsage); err != nil {
+		return fmt.Errorf("setting `subnet_usage`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualNetworkIPAvailabilityDataSource struct{}
+
+func TestAccDataSourceVirtualNetworkIPAvailability_ipAddress(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_network_ip_availability", "test")
+	r := VirtualNetworkIPAvailabilityDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.ipAddress(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("available").HasValue("false"),
+				check.That(data.ResourceName).Key("available_ip_addresses.#").Exists(),
+				check.That(data.ResourceName).Key("subnet_usage.#").HasValue("1"),
+				check.That(data.ResourceName).Key("subnet_usage.0.current_value").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceVirtualNetworkIPAvailability_subnet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_network_ip_availability", "test")
+	r := VirtualNetworkIPAvailabilityDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.subnet(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("next_available_ip_address").HasValue("10.0.2.5"),
+			),
+		},
+	})
+}
+
+func (VirtualNetworkIPAvailabilityDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  name                = "acctestvn-%d"
+  resource_group_name = azurestack_resource_group.test.name
+  location            = azurestack_resource_group.test.location
+  address_space       = ["10.0.0.0/16"]
+}
+
+resource "azurestack_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurestack_resource_group.test.name
+  virtual_network_name = azurestack_virtual_network.test.name
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurestack_network_interface" "test" {
+  name                = "acctestni-%d"
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  ip_configuration {
+    name                          = "primary"
+    subnet_id                     = azurestack_subnet.test.id
+    private_ip_address_allocation = "Static"
+    private_ip_address            = "10.0.2.4"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
+func (r VirtualNetworkIPAvailabilityDataSource) ipAddress(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_network_ip_availability" "test" {
+  virtual_network_name = azurestack_virtual_network.test.name
+  resource_group_name  = azurestack_resource_group.test.name
+  ip_address           = azurestack_network_interface.test.private_ip_address
+}
+`, r.template(data))
+}
+
+func (r VirtualNetworkIPAvailabilityDataSource) subnet(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_network_ip_availability" "test" {
+  virtual_network_name = azurestack_virtual_network.test.name
+  resource_group_name  = azurestack_resource_group.test.name
+  subnet_name          = azurestack_subnet.test.name
+
+  depends_on = [azurestack_network_interface.test]
+}
+`, r.template(data))
+}
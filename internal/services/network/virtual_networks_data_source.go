@@ -0,0 +1,181 @@
+package network
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/network/mgmt/network"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/az/tags"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/timeouts"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+func virtualNetworksDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: virtualNetworksDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"resource_group_name": commonschema.ResourceGroupNameOptional(),
+
+			"name_prefix": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"required_tags": tags.Schema(),
+
+			"attachment_status": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Attached",
+					"Unattached",
+				}, false),
+			},
+
+			"virtual_networks": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"location": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"address_space": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+						"subnets": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem:     &pluginsdk.Schema{Type: pluginsdk.TypeString},
+						},
+						"tags": tags.SchemaDataSource(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func virtualNetworksDataSourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Network.VnetClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	var iterator network.VirtualNetworkListResultIterator
+	var err error
+	if resourceGroup != "" {
+		log.Printf("[DEBUG] Reading Virtual Networks in Resource Group %q", resourceGroup)
+		iterator, err = client.ListComplete(ctx, resourceGroup)
+	} else {
+		log.Printf("[DEBUG] Reading Virtual Networks in the Subscription")
+		iterator, err = client.ListAllComplete(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("listing Virtual Networks: %+v", err)
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	requiredTags := d.Get("required_tags").(map[string]interface{})
+	attachmentStatus := d.Get("attachment_status").(string)
+
+	results := make([]interface{}, 0)
+	for iterator.NotDone() {
+		element := iterator.Value()
+
+		// a Virtual Network is considered attached when any of its Subnets are in use by an IP Configuration
+		isAttached := false
+		if props := element.VirtualNetworkPropertiesFormat; props != nil && props.Subnets != nil {
+			for _, subnet := range *props.Subnets {
+				if subnet.SubnetPropertiesFormat != nil && subnet.SubnetPropertiesFormat.IPConfigurations != nil && len(*subnet.SubnetPropertiesFormat.IPConfigurations) > 0 {
+					isAttached = true
+					break
+				}
+			}
+		}
+
+		include := true
+		if namePrefix != "" && (element.Name == nil || !strings.HasPrefix(*element.Name, namePrefix)) {
+			include = false
+		}
+		if !tags.MatchesAll(element.Tags, requiredTags) {
+			include = false
+		}
+		if attachmentStatus == "Attached" && !isAttached {
+			include = false
+		}
+		if attachmentStatus == "Unattached" && isAttached {
+			include = false
+		}
+
+		if include {
+			results = append(results, flattenDataSourceVirtualNetwork(element))
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return fmt.Errorf("enumerating Virtual Networks: %+v", err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("virtual_networks", results); err != nil {
+		return fmt.Errorf("setting `virtual_networks`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenDataSourceVirtualNetwork(input network.VirtualNetwork) map[string]interface{} {
+	id := ""
+	if input.ID != nil {
+		id = *input.ID
+	}
+
+	name := ""
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	addressSpace := make([]interface{}, 0)
+	subnets := make([]interface{}, 0)
+	if props := input.VirtualNetworkPropertiesFormat; props != nil {
+		if props.AddressSpace != nil {
+			addressSpace = utils.FlattenStringSlice(props.AddressSpace.AddressPrefixes)
+		}
+		subnets = flattenVnetSubnetsNames(props.Subnets)
+	}
+
+	return map[string]interface{}{
+		"id":            id,
+		"name":          name,
+		"location":      location.NormalizeNilable(input.Location),
+		"address_space": addressSpace,
+		"subnets":       subnets,
+		"tags":          tags.Flatten(input.Tags),
+	}
+}
@@ -0,0 +1,110 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/tf/acceptance/check"
+)
+
+type VirtualNetworksDataSource struct{}
+
+func TestAccVirtualNetworksDataSource_namePrefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_networks", "test")
+	r := VirtualNetworksDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.namePrefix(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("virtual_networks.#").HasValue("2"),
+				check.That(data.ResourceName).Key("virtual_networks.0.name").HasValue(fmt.Sprintf("acctestvneta%d-0", data.RandomInteger)),
+				check.That(data.ResourceName).Key("virtual_networks.0.address_space.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccVirtualNetworksDataSource_requiredTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurestack_virtual_networks", "test")
+	r := VirtualNetworksDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.requiredTags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("virtual_networks.#").HasValue("1"),
+				check.That(data.ResourceName).Key("virtual_networks.0.name").HasValue(fmt.Sprintf("acctestvnetb%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("virtual_networks.0.tags.environment").HasValue("production"),
+			),
+		},
+	})
+}
+
+func (VirtualNetworksDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurestack" {
+  features {}
+}
+
+resource "azurestack_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurestack_virtual_network" "test" {
+  count               = 2
+  name                = "acctestvneta%d-${count.index}"
+  address_space       = ["10.${count.index}.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  tags = {
+    environment = "test"
+  }
+}
+
+resource "azurestack_virtual_network" "test2" {
+  name                = "acctestvnetb%d"
+  address_space       = ["10.10.0.0/16"]
+  location            = azurestack_resource_group.test.location
+  resource_group_name = azurestack_resource_group.test.name
+
+  tags = {
+    environment = "production"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
+func (r VirtualNetworksDataSource) namePrefix(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_networks" "test" {
+  resource_group_name = azurestack_resource_group.test.name
+  name_prefix         = "acctestvneta"
+}
+`, r.template(data))
+}
+
+func (r VirtualNetworksDataSource) requiredTags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurestack_virtual_networks" "test" {
+  resource_group_name = azurestack_resource_group.test.name
+
+  required_tags = {
+    environment = "production"
+  }
+}
+`, r.template(data))
+}
@@ -0,0 +1,422 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+)
+
+const (
+	linuxVirtualMachineResourceType   = "azurestack_linux_virtual_machine"
+	windowsVirtualMachineResourceType = "azurestack_windows_virtual_machine"
+)
+
+// liveVirtualMachine contains the Virtual Machine and related resources, as retrieved from the API
+type liveVirtualMachine struct {
+	VirtualMachine compute.VirtualMachine
+
+	// DataDisks is a map of the (lower-cased) Resource ID to the Managed Disks attached to the Virtual Machine
+	DataDisks map[string]compute.Disk
+
+	Extensions []compute.VirtualMachineExtension
+}
+
+// generateMigration returns the configuration required to manage the legacy Virtual Machine using the
+// `azurestack_linux_virtual_machine` or `azurestack_windows_virtual_machine` resources, alongside separate
+// Managed Disks, Data Disk Attachments and Virtual Machine Extensions - and the `import` blocks required to move
+// these into the state without recreating them. The returned boolean is false when the Virtual Machine can't be
+// migrated automatically, in which case the configuration only contains comments explaining why.
+func generateMigration(vm legacyVirtualMachine, live liveVirtualMachine, managedResources map[string]string) (string, bool, error) {
+	id, err := parse.VirtualMachineID(vm.ID)
+	if err != nil {
+		return "", false, fmt.Errorf("parsing %q: %+v", vm.ID, err)
+	}
+
+	root := &hclBody{}
+	root.comment("Migration of %s (%s)", vm.Address, id.ID())
+	root.comment("")
+
+	props := live.VirtualMachine.VirtualMachineProperties
+	if props == nil || props.StorageProfile == nil || props.StorageProfile.OsDisk == nil {
+		return "", false, fmt.Errorf("`properties.storageProfile.osDisk` was nil for %s", *id)
+	}
+
+	osDisk := props.StorageProfile.OsDisk
+	if osDisk.Vhd != nil || osDisk.ManagedDisk == nil {
+		root.comment("This Virtual Machine can't be migrated automatically since it uses Unmanaged Disks, which the")
+		root.comment("`%s` and `%s` resources don't support.", linuxVirtualMachineResourceType, windowsVirtualMachineResourceType)
+		root.comment("The Virtual Machine must be converted to use Managed Disks (e.g. using the")
+		root.comment("`azurestack_virtual_machine_managed_disk_conversion` resource) before it can be migrated.")
+		return root.String(), false, nil
+	}
+	if props.OsProfile == nil {
+		root.comment("This Virtual Machine can't be migrated automatically since it was created from an existing OS Disk,")
+		root.comment("which the `%s` and `%s` resources don't support.", linuxVirtualMachineResourceType, windowsVirtualMachineResourceType)
+		return root.String(), false, nil
+	}
+
+	resourceType := linuxVirtualMachineResourceType
+	switch osDisk.OsType {
+	case compute.Linux:
+	case compute.Windows:
+		resourceType = windowsVirtualMachineResourceType
+	default:
+		return "", false, fmt.Errorf("determining the Operating System of %s: expected `Linux` or `Windows` but got %q", *id, string(osDisk.OsType))
+	}
+	virtualMachineAddress := fmt.Sprintf("%s.%s", resourceType, vm.LocalName)
+
+	root.comment("1. Replace the `%s` block in your configuration with the blocks below.", vm.ResourceAddress)
+	if vm.ModulePath != "" {
+		root.comment("   The `resource` and `variable` blocks belong in %s, whereas the `import` and `removed` blocks", vm.ModulePath)
+		root.comment("   must be placed in the root module.")
+	}
+	root.comment("2. Run `terraform plan` and confirm that no resources will be destroyed, then run `terraform apply`.")
+	root.comment("")
+	root.comment("Terraform 1.7 or later is required for `removed` blocks and 1.5 or later for `import` blocks - on older")
+	root.comment("versions omit these blocks and instead run the commands in the comments, after running:")
+	root.comment("  terraform state rm '%s'", vm.Address)
+
+	variables, err := generateVirtualMachine(root, vm, *id, resourceType, live.VirtualMachine)
+	if err != nil {
+		return "", false, err
+	}
+	generateImport(root, vm.moduleAddress(resourceType, vm.LocalName), id.ID())
+
+	for _, name := range variables {
+		variable := root.block("variable", name)
+		variable.attribute("type", "string")
+		variable.attribute("sensitive", "true")
+	}
+
+	if err := generateDataDisks(root, vm, *id, virtualMachineAddress, live, managedResources); err != nil {
+		return "", false, err
+	}
+
+	if err := generateExtensions(root, vm, virtualMachineAddress, live.Extensions, managedResources); err != nil {
+		return "", false, err
+	}
+
+	return root.String(), true, nil
+}
+
+// generateVirtualMachine adds the Virtual Machine resource to the body, returning the names of any variables
+// which need to be defined
+func generateVirtualMachine(root *hclBody, vm legacyVirtualMachine, id parse.VirtualMachineId, resourceType string, input compute.VirtualMachine) ([]string, error) {
+	props := input.VirtualMachineProperties
+	variables := make([]string, 0)
+
+	resource := root.block("resource", resourceType, vm.LocalName)
+	resource.stringAttribute("name", id.Name)
+	resource.stringAttribute("resource_group_name", id.ResourceGroup)
+	resource.stringAttribute("location", stringValue(input.Location))
+	if props.HardwareProfile != nil {
+		resource.stringAttribute("size", string(props.HardwareProfile.VMSize))
+	}
+
+	osProfile := props.OsProfile
+	resource.stringAttribute("admin_username", stringValue(osProfile.AdminUsername))
+	resource.stringAttribute("computer_name", stringValue(osProfile.ComputerName))
+
+	networkInterfaceIds := make([]string, 0)
+	if props.NetworkProfile != nil && props.NetworkProfile.NetworkInterfaces != nil {
+		// the first Network Interface is the Primary Network Interface
+		for _, v := range *props.NetworkProfile.NetworkInterfaces {
+			if v.ID == nil {
+				continue
+			}
+			if v.NetworkInterfaceReferenceProperties != nil && v.Primary != nil && *v.Primary {
+				networkInterfaceIds = append([]string{hclString(*v.ID)}, networkInterfaceIds...)
+				continue
+			}
+			networkInterfaceIds = append(networkInterfaceIds, hclString(*v.ID))
+		}
+	}
+	resource.attribute("network_interface_ids", fmt.Sprintf("[%s]", strings.Join(networkInterfaceIds, ", ")))
+
+	if props.AvailabilitySet != nil && props.AvailabilitySet.ID != nil {
+		resource.stringAttribute("availability_set_id", *props.AvailabilitySet.ID)
+	}
+	if props.LicenseType != nil && *props.LicenseType != "" {
+		resource.stringAttribute("license_type", *props.LicenseType)
+	}
+	if osProfile.AllowExtensionOperations != nil && !*osProfile.AllowExtensionOperations {
+		resource.attribute("allow_extension_operations", "false")
+	}
+
+	passwordVariable := fmt.Sprintf("%s_admin_password", vm.LocalName)
+	if resourceType == windowsVirtualMachineResourceType {
+		resource.comment("the Admin Password can't be retrieved from the API, as such this must be specified")
+		resource.attribute("admin_password", fmt.Sprintf("var.%s", passwordVariable))
+		variables = append(variables, passwordVariable)
+
+		if config := osProfile.WindowsConfiguration; config != nil {
+			if config.ProvisionVMAgent != nil {
+				resource.attribute("provision_vm_agent", strconv.FormatBool(*config.ProvisionVMAgent))
+			}
+			if config.EnableAutomaticUpdates != nil {
+				resource.attribute("enable_automatic_updates", strconv.FormatBool(*config.EnableAutomaticUpdates))
+			}
+			if config.TimeZone != nil && *config.TimeZone != "" {
+				resource.stringAttribute("timezone", *config.TimeZone)
+			}
+		}
+	} else {
+		config := osProfile.LinuxConfiguration
+		disablePasswordAuthentication := config != nil && config.DisablePasswordAuthentication != nil && *config.DisablePasswordAuthentication
+		resource.attribute("disable_password_authentication", strconv.FormatBool(disablePasswordAuthentication))
+		if !disablePasswordAuthentication {
+			resource.comment("the Admin Password can't be retrieved from the API, as such this must be specified")
+			resource.attribute("admin_password", fmt.Sprintf("var.%s", passwordVariable))
+			variables = append(variables, passwordVariable)
+		}
+		if config != nil && config.ProvisionVMAgent != nil {
+			resource.attribute("provision_vm_agent", strconv.FormatBool(*config.ProvisionVMAgent))
+		}
+
+		if config != nil && config.SSH != nil && config.SSH.PublicKeys != nil {
+			for _, key := range *config.SSH.PublicKeys {
+				username := sshKeyUsername(stringValue(key.Path), stringValue(osProfile.AdminUsername))
+
+				block := resource.block("admin_ssh_key")
+				block.stringAttribute("username", username)
+				block.stringAttribute("public_key", strings.TrimSpace(stringValue(key.KeyData)))
+			}
+		}
+	}
+
+	if osProfile.Secrets != nil && len(*osProfile.Secrets) > 0 {
+		resource.comment("TODO: this Virtual Machine has Key Vault Secrets in its OS Profile, which must be added as `secret` blocks")
+	}
+
+	osDisk := props.StorageProfile.OsDisk
+	osDiskBlock := resource.block("os_disk")
+	osDiskBlock.stringAttribute("name", stringValue(osDisk.Name))
+	osDiskBlock.stringAttribute("caching", string(osDisk.Caching))
+	osDiskBlock.stringAttribute("storage_account_type", string(osDisk.ManagedDisk.StorageAccountType))
+	if osDisk.DiskSizeGB != nil {
+		osDiskBlock.attribute("disk_size_gb", strconv.Itoa(int(*osDisk.DiskSizeGB)))
+	}
+
+	if image := props.StorageProfile.ImageReference; image != nil {
+		if image.ID != nil && *image.ID != "" {
+			resource.stringAttribute("source_image_id", *image.ID)
+		} else {
+			block := resource.block("source_image_reference")
+			block.stringAttribute("publisher", stringValue(image.Publisher))
+			block.stringAttribute("offer", stringValue(image.Offer))
+			block.stringAttribute("sku", stringValue(image.Sku))
+			block.stringAttribute("version", stringValue(image.Version))
+		}
+	}
+
+	if input.Plan != nil {
+		block := resource.block("plan")
+		block.stringAttribute("name", stringValue(input.Plan.Name))
+		block.stringAttribute("product", stringValue(input.Plan.Product))
+		block.stringAttribute("publisher", stringValue(input.Plan.Publisher))
+	}
+
+	if profile := props.DiagnosticsProfile; profile != nil && profile.BootDiagnostics != nil {
+		if diagnostics := profile.BootDiagnostics; diagnostics.Enabled != nil && *diagnostics.Enabled && diagnostics.StorageURI != nil {
+			resource.block("boot_diagnostics").stringAttribute("storage_account_uri", *diagnostics.StorageURI)
+		}
+	}
+
+	if len(input.Tags) > 0 {
+		resource.objectAttribute("tags", expandTags(input.Tags))
+	}
+
+	return variables, nil
+}
+
+func generateDataDisks(root *hclBody, vm legacyVirtualMachine, id parse.VirtualMachineId, virtualMachineAddress string, live liveVirtualMachine, managedResources map[string]string) error {
+	if live.VirtualMachine.StorageProfile.DataDisks == nil {
+		return nil
+	}
+
+	dataDisks := *live.VirtualMachine.StorageProfile.DataDisks
+	sort.Slice(dataDisks, func(i, j int) bool {
+		return int32Value(dataDisks[i].Lun) < int32Value(dataDisks[j].Lun)
+	})
+
+	for _, dataDisk := range dataDisks {
+		diskName := stringValue(dataDisk.Name)
+		if dataDisk.Vhd != nil || dataDisk.ManagedDisk == nil || dataDisk.ManagedDisk.ID == nil {
+			root.comment("TODO: the Data Disk %q uses an Unmanaged Disk, which can't be attached using the", diskName)
+			root.comment("`%s` resource - it must be converted to a Managed Disk first.", dataDiskAttachmentResourceType)
+			continue
+		}
+
+		diskId, err := parse.ManagedDiskID(*dataDisk.ManagedDisk.ID)
+		if err != nil {
+			return fmt.Errorf("parsing %q: %+v", *dataDisk.ManagedDisk.ID, err)
+		}
+		diskName = diskId.DiskName
+		diskLocalName := sanitizeLocalName(fmt.Sprintf("%s_%s", vm.LocalName, diskId.DiskName))
+
+		attachmentId := parse.NewDataDiskID(id.SubscriptionId, id.ResourceGroup, id.Name, diskId.DiskName)
+		if address, ok := managedResources[strings.ToLower(attachmentId.ID())]; ok {
+			root.comment("The Data Disk %q is already attached using %s - the `virtual_machine_id` of which should", diskName, address)
+			root.comment("reference `%s.id` instead, since the ID is unchanged this won't cause any changes.", virtualMachineAddress)
+			continue
+		}
+
+		managedDiskIdExpression := fmt.Sprintf("%s.%s.id", managedDiskResourceType, diskLocalName)
+		if address, ok := managedResources[strings.ToLower(diskId.ID())]; ok {
+			managedDiskIdExpression = vm.referenceExpression(address, diskId.ID())
+		} else {
+			disk, ok := live.DataDisks[strings.ToLower(diskId.ID())]
+			if !ok {
+				return fmt.Errorf("the Managed Disk %s attached to %s was not retrieved", *diskId, id)
+			}
+			generateManagedDisk(root, diskLocalName, *diskId, disk)
+			generateImport(root, vm.moduleAddress(managedDiskResourceType, diskLocalName), diskId.ID())
+		}
+
+		attachment := root.block("resource", dataDiskAttachmentResourceType, diskLocalName)
+		attachment.attribute("managed_disk_id", managedDiskIdExpression)
+		attachment.attribute("virtual_machine_id", fmt.Sprintf("%s.id", virtualMachineAddress))
+		attachment.attribute("lun", strconv.Itoa(int(int32Value(dataDisk.Lun))))
+		attachment.stringAttribute("caching", string(dataDisk.Caching))
+		if dataDisk.WriteAcceleratorEnabled != nil && *dataDisk.WriteAcceleratorEnabled {
+			attachment.attribute("write_accelerator_enabled", "true")
+		}
+		generateImport(root, vm.moduleAddress(dataDiskAttachmentResourceType, diskLocalName), attachmentId.ID())
+	}
+
+	return nil
+}
+
+func generateManagedDisk(root *hclBody, localName string, id parse.ManagedDiskId, disk compute.Disk) {
+	resource := root.block("resource", managedDiskResourceType, localName)
+	resource.stringAttribute("name", id.DiskName)
+	resource.stringAttribute("resource_group_name", id.ResourceGroup)
+	resource.stringAttribute("location", stringValue(disk.Location))
+	if disk.Sku != nil {
+		resource.stringAttribute("storage_account_type", string(disk.Sku.Name))
+	}
+
+	if props := disk.DiskProperties; props != nil {
+		createOption := compute.DiskCreateOption("")
+		if data := props.CreationData; data != nil {
+			createOption = data.CreateOption
+		}
+		resource.stringAttribute("create_option", string(createOption))
+		if props.DiskSizeGB != nil {
+			resource.attribute("disk_size_gb", strconv.Itoa(int(*props.DiskSizeGB)))
+		}
+
+		if data := props.CreationData; data != nil {
+			if data.SourceResourceID != nil && *data.SourceResourceID != "" {
+				resource.stringAttribute("source_resource_id", *data.SourceResourceID)
+			}
+			if data.SourceURI != nil && *data.SourceURI != "" {
+				resource.stringAttribute("source_uri", *data.SourceURI)
+			}
+			if data.StorageAccountID != nil && *data.StorageAccountID != "" {
+				resource.stringAttribute("storage_account_id", *data.StorageAccountID)
+			}
+			if data.ImageReference != nil && data.ImageReference.ID != nil {
+				resource.stringAttribute("image_reference_id", *data.ImageReference.ID)
+			}
+		}
+	}
+
+	if len(disk.Tags) > 0 {
+		resource.objectAttribute("tags", expandTags(disk.Tags))
+	}
+}
+
+func generateExtensions(root *hclBody, vm legacyVirtualMachine, virtualMachineAddress string, extensions []compute.VirtualMachineExtension, managedResources map[string]string) error {
+	for _, extension := range extensions {
+		if extension.ID == nil || extension.Name == nil {
+			continue
+		}
+
+		id, err := parse.VirtualMachineExtensionID(*extension.ID)
+		if err != nil {
+			return fmt.Errorf("parsing %q: %+v", *extension.ID, err)
+		}
+
+		if address, ok := managedResources[strings.ToLower(id.ID())]; ok {
+			root.comment("The Extension %q is already managed by %s - the `virtual_machine_id` of which should", id.ExtensionName, address)
+			root.comment("reference `%s.id` instead, since the ID is unchanged this won't cause any changes.", virtualMachineAddress)
+			continue
+		}
+
+		extensionLocalName := sanitizeLocalName(fmt.Sprintf("%s_%s", vm.LocalName, id.ExtensionName))
+		resource := root.block("resource", extensionResourceType, extensionLocalName)
+		resource.stringAttribute("name", id.ExtensionName)
+		resource.attribute("virtual_machine_id", fmt.Sprintf("%s.id", virtualMachineAddress))
+
+		if props := extension.VirtualMachineExtensionProperties; props != nil {
+			resource.stringAttribute("publisher", stringValue(props.Publisher))
+			resource.stringAttribute("type", stringValue(props.Type))
+			resource.stringAttribute("type_handler_version", stringValue(props.TypeHandlerVersion))
+			if props.AutoUpgradeMinorVersion != nil && *props.AutoUpgradeMinorVersion {
+				resource.attribute("auto_upgrade_minor_version", "true")
+			}
+
+			if props.Settings != nil {
+				settings, err := hclJSONEncode(props.Settings)
+				if err != nil {
+					return fmt.Errorf("encoding the settings for %s: %+v", *id, err)
+				}
+				resource.attribute("settings", settings)
+			}
+		}
+		resource.comment("the `protected_settings` can't be retrieved from the API - adding these will update the Extension")
+
+		if len(extension.Tags) > 0 {
+			resource.objectAttribute("tags", expandTags(extension.Tags))
+		}
+
+		generateImport(root, vm.moduleAddress(extensionResourceType, extensionLocalName), id.ID())
+	}
+
+	return nil
+}
+
+func generateImport(root *hclBody, address, id string) {
+	block := root.block("import")
+	block.comment("terraform import '%s' '%s'", address, id)
+	block.attribute("to", address)
+	block.stringAttribute("id", id)
+}
+
+// sshKeyUsername returns the username from the path of the SSH Key, which is in the format
+// `/home/{username}/.ssh/authorized_keys` - falling back to the Admin Username when this can't be determined
+func sshKeyUsername(path, adminUsername string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 4 && segments[0] == "home" && segments[2] == ".ssh" && segments[3] == "authorized_keys" {
+		return segments[1]
+	}
+
+	return adminUsername
+}
+
+func expandTags(input map[string]*string) map[string]string {
+	output := make(map[string]string, len(input))
+	for k, v := range input {
+		output[k] = stringValue(v)
+	}
+	return output
+}
+
+func stringValue(input *string) string {
+	if input == nil {
+		return ""
+	}
+	return *input
+}
+
+func int32Value(input *int32) int32 {
+	if input == nil {
+		return 0
+	}
+	return *input
+}
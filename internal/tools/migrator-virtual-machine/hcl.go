@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// hclBody is a minimal writer for the subset of HCL required to generate the configuration, which aligns the
+// attributes in the same way as `terraform fmt`
+type hclBody struct {
+	items []hclItem
+}
+
+type hclItem struct {
+	// comment is written as a `#` comment when isComment is set
+	comment   string
+	isComment bool
+
+	// name is the name of the attribute or the type of the block
+	name string
+
+	// expression is the (already formatted) value of an attribute
+	expression string
+
+	// object is the value of an attribute containing a map of strings
+	object map[string]string
+
+	// block is the body of a nested block, with the labels in `labels`
+	block  *hclBody
+	labels []string
+}
+
+func (b *hclBody) comment(format string, a ...interface{}) {
+	b.items = append(b.items, hclItem{
+		comment:   fmt.Sprintf(format, a...),
+		isComment: true,
+	})
+}
+
+func (b *hclBody) attribute(name, expression string) {
+	b.items = append(b.items, hclItem{
+		name:       name,
+		expression: expression,
+	})
+}
+
+func (b *hclBody) stringAttribute(name, value string) {
+	b.attribute(name, hclString(value))
+}
+
+func (b *hclBody) objectAttribute(name string, value map[string]string) {
+	b.items = append(b.items, hclItem{
+		name:   name,
+		object: value,
+	})
+}
+
+func (b *hclBody) block(name string, labels ...string) *hclBody {
+	body := &hclBody{}
+	b.items = append(b.items, hclItem{
+		name:   name,
+		block:  body,
+		labels: labels,
+	})
+	return body
+}
+
+func (b *hclBody) String() string {
+	var sb strings.Builder
+	b.write(&sb, 0)
+	return sb.String()
+}
+
+func (b *hclBody) write(sb *strings.Builder, indent int) {
+	prefix := strings.Repeat("  ", indent)
+
+	for i := 0; i < len(b.items); i++ {
+		item := b.items[i]
+
+		switch {
+		case item.block != nil:
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(prefix + item.name)
+			for _, label := range item.labels {
+				sb.WriteString(" " + strconv.Quote(label))
+			}
+			sb.WriteString(" {\n")
+			item.block.write(sb, indent+1)
+			sb.WriteString(prefix + "}\n")
+			if i < len(b.items)-1 && b.items[i+1].block == nil {
+				sb.WriteString("\n")
+			}
+
+		case item.isComment:
+			sb.WriteString(strings.TrimRight(prefix+"# "+item.comment, " ") + "\n")
+
+		default:
+			// consecutive attributes are aligned on the `=`
+			end := i
+			width := 0
+			for end < len(b.items) && b.items[end].block == nil && !b.items[end].isComment {
+				if l := len(b.items[end].name); l > width {
+					width = l
+				}
+				end++
+			}
+
+			for ; i < end; i++ {
+				attribute := b.items[i]
+				sb.WriteString(fmt.Sprintf("%s%-*s = ", prefix, width, attribute.name))
+				if attribute.object != nil {
+					writeObject(sb, attribute.object, indent)
+				} else {
+					sb.WriteString(attribute.expression + "\n")
+				}
+			}
+			i--
+		}
+	}
+}
+
+func writeObject(sb *strings.Builder, input map[string]string, indent int) {
+	if len(input) == 0 {
+		sb.WriteString("{}\n")
+		return
+	}
+
+	keys := make([]string, 0, len(input))
+	width := 0
+	for k := range input {
+		keys = append(keys, k)
+		if l := len(hclString(k)); l > width {
+			width = l
+		}
+	}
+	sort.Strings(keys)
+
+	prefix := strings.Repeat("  ", indent)
+	sb.WriteString("{\n")
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("%s  %-*s = %s\n", prefix, width, hclString(k), hclString(input[k])))
+	}
+	sb.WriteString(prefix + "}\n")
+}
+
+// hclString returns the input as a quoted HCL string, escaping any template sequences
+func hclString(input string) string {
+	var sb strings.Builder
+	sb.WriteString(`"`)
+	for _, r := range input {
+		switch {
+		case r == '"':
+			sb.WriteString(`\"`)
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteString(`"`)
+
+	return escapeTemplateSequences(sb.String())
+}
+
+// hclJSONEncode returns an expression which encodes the input as JSON, for use in fields such as `settings`
+func hclJSONEncode(input interface{}) (string, error) {
+	value, err := hclValue(input)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("jsonencode(%s)", value), nil
+}
+
+// hclValue returns the input (as decoded from JSON) as a single-line HCL expression
+func hclValue(input interface{}) (string, error) {
+	switch v := input.(type) {
+	case nil:
+		return "null", nil
+
+	case bool:
+		return strconv.FormatBool(v), nil
+
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+
+	case json.Number:
+		return v.String(), nil
+
+	case string:
+		return hclString(v), nil
+
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			value, err := hclValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, value)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ", ")), nil
+
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}", nil
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, 0, len(v))
+		for _, k := range keys {
+			value, err := hclValue(v[k])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, fmt.Sprintf("%s = %s", hclString(k), value))
+		}
+		return fmt.Sprintf("{ %s }", strings.Join(items, ", ")), nil
+	}
+
+	return "", fmt.Errorf("unsupported type %T", input)
+}
+
+func escapeTemplateSequences(input string) string {
+	input = strings.ReplaceAll(input, "${", "$${")
+	return strings.ReplaceAll(input, "%{", "%%{")
+}
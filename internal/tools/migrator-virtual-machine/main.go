@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/go-azure-helpers/authentication"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/services/compute/parse"
+)
+
+func main() {
+	statePath := flag.String("state", "", "The path to the Terraform State, as output by `terraform state pull` - or `-` to read from stdin")
+	outputPath := flag.String("output", "", "The path to write the generated configuration to, defaults to stdout")
+	showHelp := flag.Bool("help", false, "Display this message")
+
+	flag.Parse()
+
+	if *showHelp || *statePath == "" {
+		flag.Usage()
+		return
+	}
+
+	if err := run(*statePath, *outputPath); err != nil {
+		panic(err)
+	}
+}
+
+func run(statePath, outputPath string) error {
+	input := os.Stdin
+	if statePath != "-" {
+		file, err := os.Open(statePath)
+		if err != nil {
+			return fmt.Errorf("opening the Terraform State at %q: %+v", statePath, err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	summary, err := parseState(input)
+	if err != nil {
+		return err
+	}
+	if len(summary.VirtualMachines) == 0 {
+		return fmt.Errorf("no `%s` resources were found in the Terraform State", legacyVirtualMachineResourceType)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	client, err := buildClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	migrations := make([]string, 0)
+	migratedResources := make(map[string]bool)
+	for _, vm := range summary.VirtualMachines {
+		live, err := retrieveVirtualMachine(ctx, client, vm)
+		if err != nil {
+			return err
+		}
+
+		migration, migrated, err := generateMigration(vm, *live, summary.ManagedResources)
+		if err != nil {
+			return fmt.Errorf("generating the migration for %s: %+v", vm.Address, err)
+		}
+		migrations = append(migrations, migration)
+
+		// the `removed` block applies to every instance of the resource, so can only be used once they can all be migrated
+		if existing, ok := migratedResources[vm.ResourceAddress]; !ok || existing {
+			migratedResources[vm.ResourceAddress] = migrated
+		}
+	}
+
+	output := io.Writer(os.Stdout)
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("creating %q: %+v", outputPath, err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	if _, err := io.WriteString(output, generateRemovedBlocks(summary.VirtualMachines, migratedResources)+"\n"+strings.Join(migrations, "\n")); err != nil {
+		return fmt.Errorf("writing the generated configuration: %+v", err)
+	}
+
+	return nil
+}
+
+// generateRemovedBlocks returns the `removed` blocks for each legacy Virtual Machine resource which can be migrated,
+// these remove the legacy resource from the state without destroying the Virtual Machine
+func generateRemovedBlocks(virtualMachines []legacyVirtualMachine, migratedResources map[string]bool) string {
+	root := &hclBody{}
+	root.comment("These `removed` blocks must be placed in the root module, and remove the legacy resources from the state")
+	root.comment("without destroying the Virtual Machines.")
+
+	seen := make(map[string]struct{})
+	for _, vm := range virtualMachines {
+		if _, ok := seen[vm.ResourceAddress]; ok {
+			continue
+		}
+		seen[vm.ResourceAddress] = struct{}{}
+
+		if !migratedResources[vm.ResourceAddress] {
+			root.comment("%s can't be removed from the state until all of its instances can be migrated", vm.ResourceAddress)
+			continue
+		}
+
+		removed := root.block("removed")
+		removed.attribute("from", vm.ResourceAddress)
+		removed.block("lifecycle").attribute("destroy", "false")
+	}
+
+	return root.String()
+}
+
+func buildClient(ctx context.Context) (*clients.Client, error) {
+	builder := &authentication.Builder{
+		SubscriptionID:     os.Getenv("ARM_SUBSCRIPTION_ID"),
+		ClientID:           os.Getenv("ARM_CLIENT_ID"),
+		ClientSecret:       os.Getenv("ARM_CLIENT_SECRET"),
+		TenantID:           os.Getenv("ARM_TENANT_ID"),
+		Environment:        os.Getenv("ARM_ENVIRONMENT"),
+		MetadataHost:       os.Getenv("ARM_METADATA_HOSTNAME"),
+		ClientCertPassword: os.Getenv("ARM_CLIENT_CERTIFICATE_PASSWORD"),
+		ClientCertPath:     os.Getenv("ARM_CLIENT_CERTIFICATE_PATH"),
+
+		// Feature Toggles
+		SupportsClientCertAuth:   true,
+		SupportsClientSecretAuth: true,
+		SupportsAzureCliToken:    true,
+	}
+
+	config, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("building the Azurestack Client Config: %+v", err)
+	}
+
+	client, err := clients.Build(ctx, clients.ClientBuilder{
+		AuthConfig:               config,
+		SkipProviderRegistration: true,
+		TerraformVersion:         "0.11+compatible",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building the Azurestack Client: %+v", err)
+	}
+
+	return client, nil
+}
+
+func retrieveVirtualMachine(ctx context.Context, client *clients.Client, vm legacyVirtualMachine) (*liveVirtualMachine, error) {
+	id, err := parse.VirtualMachineID(vm.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q for %s: %+v", vm.ID, vm.Address, err)
+	}
+
+	virtualMachine, err := client.Compute.VMClient.Get(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	live := liveVirtualMachine{
+		VirtualMachine: virtualMachine,
+		DataDisks:      make(map[string]compute.Disk),
+		Extensions:     make([]compute.VirtualMachineExtension, 0),
+	}
+
+	if props := virtualMachine.VirtualMachineProperties; props != nil && props.StorageProfile != nil && props.StorageProfile.DataDisks != nil {
+		for _, dataDisk := range *props.StorageProfile.DataDisks {
+			if dataDisk.ManagedDisk == nil || dataDisk.ManagedDisk.ID == nil {
+				continue
+			}
+
+			diskId, err := parse.ManagedDiskID(*dataDisk.ManagedDisk.ID)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %q: %+v", *dataDisk.ManagedDisk.ID, err)
+			}
+
+			disk, err := client.Compute.DisksClient.Get(ctx, diskId.ResourceGroup, diskId.DiskName)
+			if err != nil {
+				return nil, fmt.Errorf("retrieving %s: %+v", *diskId, err)
+			}
+			live.DataDisks[strings.ToLower(diskId.ID())] = disk
+		}
+	}
+
+	extensions, err := client.Compute.VMExtensionClient.List(ctx, id.ResourceGroup, id.Name, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing the Extensions for %s: %+v", *id, err)
+	}
+	if extensions.Value != nil {
+		live.Extensions = *extensions.Value
+	}
+
+	return &live, nil
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/2020-09-01/compute/mgmt/compute"
+	"github.com/hashicorp/terraform-provider-azurestack/internal/utils"
+)
+
+const (
+	testVirtualMachineId = "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/machine1"
+	testManagedDiskId    = "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/disks/data1"
+	testExtensionId      = "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/machine1/extensions/script"
+)
+
+func TestParseState(t *testing.T) {
+	input := `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "azurestack_virtual_machine",
+      "name": "single",
+      "instances": [{"attributes": {"id": "` + testVirtualMachineId + `"}}]
+    },
+    {
+      "module": "module.web",
+      "mode": "managed",
+      "type": "azurestack_virtual_machine",
+      "name": "counted",
+      "instances": [
+        {"index_key": 0, "attributes": {"id": "` + testVirtualMachineId + `0"}},
+        {"index_key": 1, "attributes": {"id": "` + testVirtualMachineId + `1"}}
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "azurestack_virtual_machine",
+      "name": "each",
+      "instances": [{"index_key": "first.one", "attributes": {"id": "` + testVirtualMachineId + `2"}}]
+    },
+    {
+      "mode": "data",
+      "type": "azurestack_virtual_machine",
+      "name": "ignored",
+      "instances": [{"attributes": {"id": "` + testVirtualMachineId + `3"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "azurestack_managed_disk",
+      "name": "data",
+      "instances": [{"attributes": {"id": "` + testManagedDiskId + `"}}]
+    }
+  ]
+}`
+
+	actual, err := parseState(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parsing state: %+v", err)
+	}
+
+	expected := []legacyVirtualMachine{
+		{
+			Address:         "azurestack_virtual_machine.single",
+			ResourceAddress: "azurestack_virtual_machine.single",
+			LocalName:       "single",
+			ID:              testVirtualMachineId,
+		},
+		{
+			Address:         "module.web.azurestack_virtual_machine.counted[0]",
+			ResourceAddress: "module.web.azurestack_virtual_machine.counted",
+			ModulePath:      "module.web",
+			LocalName:       "counted_0",
+			ID:              testVirtualMachineId + "0",
+		},
+		{
+			Address:         "module.web.azurestack_virtual_machine.counted[1]",
+			ResourceAddress: "module.web.azurestack_virtual_machine.counted",
+			ModulePath:      "module.web",
+			LocalName:       "counted_1",
+			ID:              testVirtualMachineId + "1",
+		},
+		{
+			Address:         `azurestack_virtual_machine.each["first.one"]`,
+			ResourceAddress: "azurestack_virtual_machine.each",
+			LocalName:       "each_first_one",
+			ID:              testVirtualMachineId + "2",
+		},
+	}
+	if len(actual.VirtualMachines) != len(expected) {
+		t.Fatalf("Expected %d Virtual Machines but got %d", len(expected), len(actual.VirtualMachines))
+	}
+	for i, v := range expected {
+		if actual.VirtualMachines[i] != v {
+			t.Fatalf("Expected %+v but got %+v", v, actual.VirtualMachines[i])
+		}
+	}
+
+	if address := actual.ManagedResources[strings.ToLower(testManagedDiskId)]; address != "azurestack_managed_disk.data" {
+		t.Fatalf("Expected the Managed Disk to be at `azurestack_managed_disk.data` but got %q", address)
+	}
+
+	if _, err := parseState(strings.NewReader(`{"version": 3}`)); err == nil {
+		t.Fatalf("Expected an error for a version 3 state but didn't get one")
+	}
+}
+
+func TestSanitizeLocalName(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "example",
+			Expected: "example",
+		},
+		{
+			Input:    "example-vm_1",
+			Expected: "example-vm_1",
+		},
+		{
+			Input:    "example.vm/1",
+			Expected: "example_vm_1",
+		},
+		{
+			Input:    "1example",
+			Expected: "r_1example",
+		},
+		{
+			Input:    "...",
+			Expected: "r_",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Input)
+
+		if actual := sanitizeLocalName(testCase.Input); actual != testCase.Expected {
+			t.Fatalf("Expected %q but got %q", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestHclString(t *testing.T) {
+	testCases := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "hello",
+			Expected: `"hello"`,
+		},
+		{
+			Input:    `say "hello"\`,
+			Expected: `"say \"hello\"\\"`,
+		},
+		{
+			Input:    "line1\nline2\t",
+			Expected: `"line1\nline2\t"`,
+		},
+		{
+			Input:    "${var.foo} %{if}",
+			Expected: `"$${var.foo} %%{if}"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running %q..", testCase.Input)
+
+		if actual := hclString(testCase.Input); actual != testCase.Expected {
+			t.Fatalf("Expected %s but got %s", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestHclJSONEncode(t *testing.T) {
+	actual, err := hclJSONEncode(map[string]interface{}{
+		"fileUris": []interface{}{"https://example.com/script.sh"},
+		"enabled":  true,
+		"count":    float64(2),
+		"nested":   map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatalf("encoding: %+v", err)
+	}
+
+	expected := `jsonencode({ "count" = 2, "enabled" = true, "fileUris" = ["https://example.com/script.sh"], "nested" = {} })`
+	if actual != expected {
+		t.Fatalf("Expected %s but got %s", expected, actual)
+	}
+}
+
+func TestGenerateMigrationLinux(t *testing.T) {
+	vm := legacyVirtualMachine{
+		Address:         "module.web.azurestack_virtual_machine.example[0]",
+		ResourceAddress: "module.web.azurestack_virtual_machine.example",
+		ModulePath:      "module.web",
+		LocalName:       "example_0",
+		ID:              testVirtualMachineId,
+	}
+	live := testLiveVirtualMachine(compute.Linux)
+
+	actual, migrated, err := generateMigration(vm, live, map[string]string{})
+	if err != nil {
+		t.Fatalf("generating migration: %+v", err)
+	}
+	if !migrated {
+		t.Fatalf("Expected the Virtual Machine to be migrated")
+	}
+
+	for _, expected := range []string{
+		`resource "azurestack_linux_virtual_machine" "example_0" {`,
+		`  network_interface_ids           = ["/primary", "/secondary"]`,
+		`  disable_password_authentication = true`,
+		`    username   = "adminuser"`,
+		`    publisher = "Canonical"`,
+		`  to = module.web.azurestack_linux_virtual_machine.example_0`,
+		`resource "azurestack_managed_disk" "example_0_data1" {`,
+		`  to = module.web.azurestack_managed_disk.example_0_data1`,
+		`  managed_disk_id    = azurestack_managed_disk.example_0_data1.id`,
+		`  virtual_machine_id = azurestack_linux_virtual_machine.example_0.id`,
+		`  id = "` + testVirtualMachineId + `/dataDisks/data1"`,
+		`resource "azurestack_virtual_machine_extension" "example_0_script" {`,
+		`  settings             = jsonencode({ "commandToExecute" = "echo $${HOME}" })`,
+		`  id = "` + testExtensionId + `"`,
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Fatalf("Expected the configuration to contain %q:\n\n%s", expected, actual)
+		}
+	}
+	if strings.Contains(actual, `variable "`) {
+		t.Fatalf("Expected no variables when password authentication is disabled:\n\n%s", actual)
+	}
+}
+
+func TestGenerateMigrationWindows(t *testing.T) {
+	vm := legacyVirtualMachine{
+		Address:         "azurestack_virtual_machine.example",
+		ResourceAddress: "azurestack_virtual_machine.example",
+		LocalName:       "example",
+		ID:              testVirtualMachineId,
+	}
+	live := testLiveVirtualMachine(compute.Windows)
+
+	// the Managed Disk and Extension are already managed, so should only be referenced
+	managedResources := map[string]string{
+		strings.ToLower(testManagedDiskId): "azurestack_managed_disk.existing",
+		strings.ToLower(testExtensionId):   "module.other.azurestack_virtual_machine_extension.existing",
+	}
+
+	actual, migrated, err := generateMigration(vm, live, managedResources)
+	if err != nil {
+		t.Fatalf("generating migration: %+v", err)
+	}
+	if !migrated {
+		t.Fatalf("Expected the Virtual Machine to be migrated")
+	}
+
+	for _, expected := range []string{
+		`resource "azurestack_windows_virtual_machine" "example" {`,
+		`  admin_password     = var.example_admin_password`,
+		`variable "example_admin_password" {`,
+		`  managed_disk_id    = azurestack_managed_disk.existing.id`,
+		`The Extension "script" is already managed by module.other.azurestack_virtual_machine_extension.existing`,
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Fatalf("Expected the configuration to contain %q:\n\n%s", expected, actual)
+		}
+	}
+	if strings.Contains(actual, `resource "azurestack_managed_disk"`) || strings.Contains(actual, `resource "azurestack_virtual_machine_extension"`) {
+		t.Fatalf("Expected the existing resources not to be generated:\n\n%s", actual)
+	}
+}
+
+func TestGenerateMigrationUnmanagedDisks(t *testing.T) {
+	vm := legacyVirtualMachine{
+		Address:         "azurestack_virtual_machine.example",
+		ResourceAddress: "azurestack_virtual_machine.example",
+		LocalName:       "example",
+		ID:              testVirtualMachineId,
+	}
+	live := testLiveVirtualMachine(compute.Linux)
+	live.VirtualMachine.StorageProfile.OsDisk.ManagedDisk = nil
+	live.VirtualMachine.StorageProfile.OsDisk.Vhd = &compute.VirtualHardDisk{
+		URI: utils.String("https://example.blob.core.windows.net/vhds/os.vhd"),
+	}
+
+	actual, migrated, err := generateMigration(vm, live, map[string]string{})
+	if err != nil {
+		t.Fatalf("generating migration: %+v", err)
+	}
+	if migrated {
+		t.Fatalf("Expected the Virtual Machine not to be migrated")
+	}
+	if strings.Contains(actual, `resource "`) {
+		t.Fatalf("Expected no resources to be generated:\n\n%s", actual)
+	}
+
+	removed := generateRemovedBlocks([]legacyVirtualMachine{vm}, map[string]bool{vm.ResourceAddress: migrated})
+	if strings.Contains(removed, "removed {") {
+		t.Fatalf("Expected no `removed` blocks to be generated:\n\n%s", removed)
+	}
+}
+
+func TestGenerateRemovedBlocks(t *testing.T) {
+	virtualMachines := []legacyVirtualMachine{
+		{ResourceAddress: "module.web.azurestack_virtual_machine.example"},
+		{ResourceAddress: "module.web.azurestack_virtual_machine.example"},
+		{ResourceAddress: "azurestack_virtual_machine.other"},
+	}
+
+	actual := generateRemovedBlocks(virtualMachines, map[string]bool{
+		"module.web.azurestack_virtual_machine.example": true,
+		"azurestack_virtual_machine.other":              true,
+	})
+
+	if count := strings.Count(actual, "removed {"); count != 2 {
+		t.Fatalf("Expected 2 `removed` blocks but got %d:\n\n%s", count, actual)
+	}
+	if !strings.Contains(actual, "  from = module.web.azurestack_virtual_machine.example\n") {
+		t.Fatalf("Expected a `removed` block for the module:\n\n%s", actual)
+	}
+}
+
+func testLiveVirtualMachine(osType compute.OperatingSystemTypes) liveVirtualMachine {
+	osProfile := &compute.OSProfile{
+		AdminUsername: utils.String("adminuser"),
+		ComputerName:  utils.String("machine1"),
+	}
+	if osType == compute.Windows {
+		osProfile.WindowsConfiguration = &compute.WindowsConfiguration{
+			ProvisionVMAgent: utils.Bool(true),
+		}
+	} else {
+		osProfile.LinuxConfiguration = &compute.LinuxConfiguration{
+			DisablePasswordAuthentication: utils.Bool(true),
+			SSH: &compute.SSHConfiguration{
+				PublicKeys: &[]compute.SSHPublicKey{
+					{
+						Path:    utils.String("/home/adminuser/.ssh/authorized_keys"),
+						KeyData: utils.String("ssh-rsa AAAA"),
+					},
+				},
+			},
+		}
+	}
+
+	return liveVirtualMachine{
+		VirtualMachine: compute.VirtualMachine{
+			Location: utils.String("local"),
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				HardwareProfile: &compute.HardwareProfile{
+					VMSize: compute.VirtualMachineSizeTypes("Standard_F2"),
+				},
+				OsProfile: osProfile,
+				NetworkProfile: &compute.NetworkProfile{
+					NetworkInterfaces: &[]compute.NetworkInterfaceReference{
+						{
+							ID: utils.String("/secondary"),
+						},
+						{
+							ID: utils.String("/primary"),
+							NetworkInterfaceReferenceProperties: &compute.NetworkInterfaceReferenceProperties{
+								Primary: utils.Bool(true),
+							},
+						},
+					},
+				},
+				StorageProfile: &compute.StorageProfile{
+					ImageReference: &compute.ImageReference{
+						Publisher: utils.String("Canonical"),
+						Offer:     utils.String("UbuntuServer"),
+						Sku:       utils.String("16.04-LTS"),
+						Version:   utils.String("latest"),
+					},
+					OsDisk: &compute.OSDisk{
+						Name:    utils.String("osdisk1"),
+						OsType:  osType,
+						Caching: compute.CachingTypesReadWrite,
+						ManagedDisk: &compute.ManagedDiskParameters{
+							StorageAccountType: compute.StorageAccountTypesStandardLRS,
+						},
+					},
+					DataDisks: &[]compute.DataDisk{
+						{
+							Lun:     utils.Int32(0),
+							Name:    utils.String("data1"),
+							Caching: compute.CachingTypesNone,
+							ManagedDisk: &compute.ManagedDiskParameters{
+								ID: utils.String(testManagedDiskId),
+							},
+						},
+					},
+				},
+			},
+		},
+		DataDisks: map[string]compute.Disk{
+			strings.ToLower(testManagedDiskId): {
+				Location: utils.String("local"),
+				Sku: &compute.DiskSku{
+					Name: compute.StandardLRS,
+				},
+				DiskProperties: &compute.DiskProperties{
+					CreationData: &compute.CreationData{
+						CreateOption: compute.Empty,
+					},
+					DiskSizeGB: utils.Int32(10),
+				},
+			},
+		},
+		Extensions: []compute.VirtualMachineExtension{
+			{
+				ID:   utils.String(testExtensionId),
+				Name: utils.String("script"),
+				VirtualMachineExtensionProperties: &compute.VirtualMachineExtensionProperties{
+					Publisher:          utils.String("Microsoft.Azure.Extensions"),
+					Type:               utils.String("CustomScript"),
+					TypeHandlerVersion: utils.String("2.0"),
+					Settings: map[string]interface{}{
+						"commandToExecute": "echo ${HOME}",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	legacyVirtualMachineResourceType = "azurestack_virtual_machine"
+	managedDiskResourceType          = "azurestack_managed_disk"
+	dataDiskAttachmentResourceType   = "azurestack_virtual_machine_data_disk_attachment"
+	extensionResourceType            = "azurestack_virtual_machine_extension"
+)
+
+// terraformState is the subset of the Terraform State (version 4) required to find the legacy Virtual Machines,
+// as output by `terraform state pull`
+type terraformState struct {
+	Version   int             `json:"version"`
+	Resources []stateResource `json:"resources"`
+}
+
+type stateResource struct {
+	Module    string          `json:"module"`
+	Mode      string          `json:"mode"`
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Instances []stateInstance `json:"instances"`
+}
+
+type stateInstance struct {
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// legacyVirtualMachine is an instance of the `azurestack_virtual_machine` resource within the state
+type legacyVirtualMachine struct {
+	// Address is the address of this instance within the state, e.g. `module.foo.azurestack_virtual_machine.bar[0]`
+	Address string
+
+	// ResourceAddress is the address of the resource containing this instance, e.g. `module.foo.azurestack_virtual_machine.bar`
+	ResourceAddress string
+
+	// ModulePath is the module containing this instance (e.g. `module.foo`), or empty for the root module
+	ModulePath string
+
+	// LocalName is the name used for the generated resources, e.g. `bar_0`
+	LocalName string
+
+	// ID is the Resource ID of the Virtual Machine
+	ID string
+}
+
+// stateSummary contains the legacy Virtual Machines within the state, alongside the related resources which
+// are already managed separately
+type stateSummary struct {
+	VirtualMachines []legacyVirtualMachine
+
+	// ManagedResources is a map of the (lower-cased) Resource ID to the address of the Managed Disks, Data Disk
+	// Attachments and Virtual Machine Extensions already within the state
+	ManagedResources map[string]string
+}
+
+func parseState(input io.Reader) (*stateSummary, error) {
+	var state terraformState
+	if err := json.NewDecoder(input).Decode(&state); err != nil {
+		return nil, fmt.Errorf("decoding the Terraform State: %+v", err)
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("expected a Terraform State with version 4 but got version %d - the state should be retrieved using `terraform state pull`", state.Version)
+	}
+
+	summary := stateSummary{
+		VirtualMachines:  make([]legacyVirtualMachine, 0),
+		ManagedResources: make(map[string]string),
+	}
+	for _, resource := range state.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+
+		for _, instance := range resource.Instances {
+			id, ok := instance.Attributes["id"].(string)
+			if !ok || id == "" {
+				continue
+			}
+
+			address := stateAddress(resource, instance)
+			switch resource.Type {
+			case legacyVirtualMachineResourceType:
+				summary.VirtualMachines = append(summary.VirtualMachines, legacyVirtualMachine{
+					Address:         address,
+					ResourceAddress: resourceAddress(resource),
+					ModulePath:      resource.Module,
+					LocalName:       localName(resource.Name, instance.IndexKey),
+					ID:              id,
+				})
+
+			case managedDiskResourceType, dataDiskAttachmentResourceType, extensionResourceType:
+				summary.ManagedResources[strings.ToLower(id)] = address
+			}
+		}
+	}
+
+	return &summary, nil
+}
+
+func resourceAddress(resource stateResource) string {
+	address := fmt.Sprintf("%s.%s", resource.Type, resource.Name)
+	if resource.Module != "" {
+		address = fmt.Sprintf("%s.%s", resource.Module, address)
+	}
+	return address
+}
+
+func stateAddress(resource stateResource, instance stateInstance) string {
+	address := resourceAddress(resource)
+
+	switch v := instance.IndexKey.(type) {
+	case string:
+		address = fmt.Sprintf("%s[%q]", address, v)
+	case float64:
+		address = fmt.Sprintf("%s[%d]", address, int(v))
+	}
+
+	return address
+}
+
+var invalidLocalNameCharacters = regexp.MustCompile("[^a-zA-Z0-9_-]+")
+
+// localName returns a valid name for a resource in the generated configuration, based on the name of the legacy
+// resource and its index within `count` or `for_each`
+func localName(name string, indexKey interface{}) string {
+	switch v := indexKey.(type) {
+	case string:
+		name = fmt.Sprintf("%s_%s", name, v)
+	case float64:
+		name = fmt.Sprintf("%s_%d", name, int(v))
+	}
+
+	return sanitizeLocalName(name)
+}
+
+func sanitizeLocalName(input string) string {
+	output := strings.Trim(invalidLocalNameCharacters.ReplaceAllString(input, "_"), "_")
+	if output == "" || (output[0] >= '0' && output[0] <= '9') || output[0] == '-' {
+		output = "r_" + output
+	}
+	return output
+}
+
+// moduleAddress returns the address of the resource within the same module as the legacy Virtual Machine
+func (vm legacyVirtualMachine) moduleAddress(resourceType, name string) string {
+	if vm.ModulePath == "" {
+		return fmt.Sprintf("%s.%s", resourceType, name)
+	}
+	return fmt.Sprintf("%s.%s.%s", vm.ModulePath, resourceType, name)
+}
+
+// referenceExpression returns an expression referencing the ID of the resource at `address` when it's within the
+// same module as the legacy Virtual Machine, otherwise the ID itself
+func (vm legacyVirtualMachine) referenceExpression(address, id string) string {
+	relative := address
+	if vm.ModulePath != "" {
+		if !strings.HasPrefix(address, vm.ModulePath+".") {
+			return hclString(id)
+		}
+		relative = strings.TrimPrefix(address, vm.ModulePath+".")
+	}
+
+	if strings.HasPrefix(relative, "module.") {
+		return hclString(id)
+	}
+
+	return fmt.Sprintf("%s.id", relative)
+}